@@ -18,28 +18,273 @@ package v1beta1
 
 import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // Cluster states.
 const (
-	ClusterStateRunning = "RUNNING"
+	ClusterStateRunning     = "RUNNING"
+	ClusterStateUnreachable = "UNREACHABLE"
 )
 
+// AnnotationKeyPaused pauses reconciliation of an ExistingCluster when set to
+// "true".
+const AnnotationKeyPaused = "crossplane.io/paused"
+
 // Defaults for Existing Cluster resources.
 const (
 	DefaultReclaimPolicy = runtimev1alpha1.ReclaimRetain
 )
 
+// Condition types.
+const (
+	// TypeClusterIdentity indicates whether an ExistingCluster connects to
+	// the cluster it was first observed to connect to.
+	TypeClusterIdentity runtimev1alpha1.ConditionType = "ClusterIdentity"
+
+	// TypeReconcilePaused indicates whether reconciliation of an
+	// ExistingCluster is paused.
+	TypeReconcilePaused runtimev1alpha1.ConditionType = "ReconcilePaused"
+
+	// TypeScrubbed indicates whether an ExistingCluster returned to an
+	// ExistingClusterPool has been scrubbed of the objects created while it
+	// was leased.
+	TypeScrubbed runtimev1alpha1.ConditionType = "Scrubbed"
+
+	// TypeFactsObserved indicates whether every fact about the cluster an
+	// ExistingCluster represents could be observed.
+	TypeFactsObserved runtimev1alpha1.ConditionType = "FactsObserved"
+)
+
+// Condition reasons.
+const (
+	ReasonIdentityVerified runtimev1alpha1.ConditionReason = "Cluster matches its recorded fingerprint"
+	ReasonIdentityChanged  runtimev1alpha1.ConditionReason = "Cluster does not match its recorded fingerprint"
+	ReasonPaused           runtimev1alpha1.ConditionReason = "Reconciliation is paused by annotation"
+	ReasonResumed          runtimev1alpha1.ConditionReason = "Reconciliation resumed"
+	ReasonScrubbing        runtimev1alpha1.ConditionReason = "Deleting objects created while leased"
+	ReasonScrubbed         runtimev1alpha1.ConditionReason = "Deleted objects created while leased"
+	ReasonFactsObserved    runtimev1alpha1.ConditionReason = "Observed all facts about the cluster"
+	ReasonFactsUnavailable runtimev1alpha1.ConditionReason = "Some facts about the cluster could not be observed"
+)
+
+// IdentityVerified returns a condition that indicates an ExistingCluster
+// connects to the cluster it was first observed to connect to.
+func IdentityVerified() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeClusterIdentity,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonIdentityVerified,
+	}
+}
+
+// IdentityChanged returns a condition that indicates an ExistingCluster no
+// longer connects to the cluster it was first observed to connect to, for
+// example because its Provider's credentials now point to another cluster.
+func IdentityChanged(msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeClusterIdentity,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonIdentityChanged,
+		Message:            msg,
+	}
+}
+
+// ReconcilePaused returns a condition that indicates reconciliation of an
+// ExistingCluster is paused.
+func ReconcilePaused() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeReconcilePaused,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPaused,
+	}
+}
+
+// ReconcileResumed returns a condition that indicates reconciliation of an
+// ExistingCluster was paused, but has resumed.
+func ReconcileResumed() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeReconcilePaused,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonResumed,
+	}
+}
+
+// Scrubbing returns a condition that indicates an ExistingCluster is being
+// scrubbed of the objects created while it was leased, and may not be leased
+// again until scrubbing succeeds.
+func Scrubbing(msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeScrubbed,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonScrubbing,
+		Message:            msg,
+	}
+}
+
+// Scrubbed returns a condition that indicates an ExistingCluster has been
+// scrubbed of the objects created while it was leased.
+func Scrubbed() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeScrubbed,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonScrubbed,
+	}
+}
+
+// FactsObserved returns a condition that indicates every fact about the
+// cluster an ExistingCluster represents was observed.
+func FactsObserved() runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeFactsObserved,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFactsObserved,
+	}
+}
+
+// FactsUnavailable returns a condition that indicates some facts about the
+// cluster an ExistingCluster represents could not be observed, for example
+// because its Provider's credentials may not list nodes.
+func FactsUnavailable(msg string) runtimev1alpha1.Condition {
+	return runtimev1alpha1.Condition{
+		Type:               TypeFactsObserved,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonFactsUnavailable,
+		Message:            msg,
+	}
+}
+
+// A ClusterFingerprint stably identifies a cluster.
+type ClusterFingerprint struct {
+	// KubeSystemUID is the UID of the cluster's kube-system namespace.
+	KubeSystemUID string `json:"kubeSystemUID"`
+
+	// CAHash is the hex encoded SHA-256 hash of the cluster's CA
+	// certificate, if any. A cluster's CA may be rotated, so the CAHash is
+	// updated when it changes and does not contribute to the cluster's
+	// identity.
+	// +optional
+	CAHash string `json:"caHash,omitempty"`
+}
+
+// A RemoteObjectReference identifies an object in an existing cluster.
+type RemoteObjectReference struct {
+	// APIVersion of the referenced object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the referenced object.
+	Kind string `json:"kind"`
+
+	// Namespace of the referenced object, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+
+	// UID of the referenced object. An object that was deleted and recreated
+	// with the same name is a different object.
+	// +optional
+	UID types.UID `json:"uid,omitempty"`
+}
+
+// A ClusterRegistration records the registration of an ExistingCluster with
+// the cluster it represents.
+type ClusterRegistration struct {
+	// ControlPlaneID identifies the control plane that manages the cluster.
+	ControlPlaneID string `json:"controlPlaneID"`
+
+	// Namespace owned by this provider in the cluster.
+	Namespace string `json:"namespace"`
+
+	// Time at which the cluster was registered.
+	Time metav1.Time `json:"time"`
+}
+
 // ExistingClusterObservation is used to show the observed state of the existing cluster cluster resource.
 type ExistingClusterObservation struct {
 	Status        string `json:"status,omitempty"`
 	StatusMessage string `json:"statusMessage,omitempty"`
 	Endpoint      string `json:"endpoint,omitempty"`
+
+	// ClientCertificateExpiry is the time at which the client certificate
+	// used to connect to the cluster expires, if any.
+	ClientCertificateExpiry *metav1.Time `json:"clientCertificateExpiry,omitempty"`
+
+	// CreatedObjects are the objects that were created in the cluster in
+	// order to manage it, in the order they were created. They are deleted
+	// when the ExistingCluster is deleted if its reclaim policy is Delete.
+	CreatedObjects []RemoteObjectReference `json:"createdObjects,omitempty"`
+
+	// Registration of this ExistingCluster with the cluster it represents.
+	// It is set once registration succeeds.
+	Registration *ClusterRegistration `json:"registration,omitempty"`
+
+	// Fingerprint of the cluster, recorded when it is first observed. The
+	// ExistingCluster is blocked from use if the cluster it connects to no
+	// longer matches this fingerprint.
+	Fingerprint *ClusterFingerprint `json:"fingerprint,omitempty"`
+
+	// ClusterID is the value of the cluster's id.k8s.io ClusterProperty, if
+	// any. It is only observed if the cluster ID policy is not Ignore.
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// KubernetesVersion is the version of the cluster's API server.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// ReadyNodes is the number of nodes in the cluster that are ready. It is
+	// omitted if the provider's credentials cannot list nodes.
+	ReadyNodes int64 `json:"readyNodes,omitempty"`
+
+	// APIGroups are the API groups served by the cluster. They are omitted
+	// if they cannot be discovered.
+	APIGroups []string `json:"apiGroups,omitempty"`
 }
 
+// A ClusterIDPolicy determines how the About API id.k8s.io ClusterProperty of
+// a cluster is managed.
+type ClusterIDPolicy string
+
+// Cluster ID policies.
+const (
+	// ClusterIDPolicyIgnore ignores the cluster's id.k8s.io ClusterProperty.
+	ClusterIDPolicyIgnore ClusterIDPolicy = "Ignore"
+
+	// ClusterIDPolicyObserve reports the cluster's existing id.k8s.io
+	// ClusterProperty, if any.
+	ClusterIDPolicyObserve ClusterIDPolicy = "Observe"
+
+	// ClusterIDPolicyCreate reports the cluster's existing id.k8s.io
+	// ClusterProperty, or creates it using the name of the ExistingCluster if
+	// it does not exist.
+	ClusterIDPolicyCreate ClusterIDPolicy = "Create"
+)
+
 // ExistingClusterParameters define the desired state of an existing cluster.
 type ExistingClusterParameters struct {
+	// ClusterIDPolicy determines how the id.k8s.io ClusterProperty defined
+	// by the KEP-2149 About API is managed in the cluster. The cluster must
+	// serve the about.k8s.io API group unless the policy is Ignore. The
+	// Ignore policy is used when no policy is specified.
+	// +optional
+	// +kubebuilder:validation:Enum=Ignore;Observe;Create
+	ClusterIDPolicy ClusterIDPolicy `json:"clusterIDPolicy,omitempty"`
+
+	// DeletionProtection prevents the ExistingCluster, and thus its
+	// connection secret, from being deleted. It must be set to false before
+	// the ExistingCluster may be deleted.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
 }
 
 // A ExistingClusterSpec defines the desired state of a ExistingCluster.
@@ -62,6 +307,8 @@ type ExistingClusterStatus struct {
 // +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
 // +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.status"
 // +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".status.atProvider.endpoint"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.kubernetesVersion",priority=1
+// +kubebuilder:printcolumn:name="NODES",type="integer",JSONPath=".status.atProvider.readyNodes",priority=1
 // +kubebuilder:printcolumn:name="CLUSTER-CLASS",type="string",JSONPath=".spec.classRef.name"
 // +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
@@ -82,3 +329,36 @@ type ExistingClusterList struct {
 	metav1.ListMeta `json:"metadata,omitempty"`
 	Items           []ExistingCluster `json:"items"`
 }
+
+// A ExistingClusterClassSpecTemplate is a template for the spec of a
+// dynamically provisioned ExistingCluster.
+type ExistingClusterClassSpecTemplate struct {
+	runtimev1alpha1.ClassSpecTemplate `json:",inline"`
+	ForProvider                       ExistingClusterParameters `json:"forProvider"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ExistingClusterClass is a resource class. It defines the desired spec of
+// resource claims that use it to dynamically provision a managed resource.
+// +kubebuilder:printcolumn:name="PROVIDER-REF",type="string",JSONPath=".specTemplate.providerRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".specTemplate.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,class}
+type ExistingClusterClass struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// SpecTemplate is a template for the spec of a dynamically provisioned
+	// ExistingCluster.
+	SpecTemplate ExistingClusterClassSpecTemplate `json:"specTemplate"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExistingClusterClassList contains a list of ExistingClusterClass items
+type ExistingClusterClassList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExistingClusterClass `json:"items"`
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations that may be set on a claim to require capabilities of the
+// ExistingCluster it is leased, in addition to the requirements of the
+// ExistingClusterPool it references.
+const (
+	AnnotationKeyMinKubernetesVersion = "container.dev.crossplane.io/min-kubernetes-version"
+	AnnotationKeyMinReadyNodes        = "container.dev.crossplane.io/min-ready-nodes"
+	AnnotationKeyAPIGroups            = "container.dev.crossplane.io/api-groups"
+)
+
+// AnnotationKeyLeaseTime is set on a claim to the time, in RFC 3339 format, at
+// which it was leased an ExistingCluster by an ExistingClusterPool. It is set
+// along with the claim's resource reference, and determines when the lease
+// expires.
+const AnnotationKeyLeaseTime = "container.dev.crossplane.io/lease-time"
+
+// ClusterRequirements are capabilities an ExistingCluster must be observed to
+// have in order to be leased.
+type ClusterRequirements struct {
+	// MinKubernetesVersion is the minimum version of the cluster's API
+	// server, for example 1.16 or v1.16.2.
+	// +optional
+	MinKubernetesVersion string `json:"minKubernetesVersion,omitempty"`
+
+	// MinReadyNodes is the minimum number of nodes in the cluster that must
+	// be ready.
+	// +optional
+	MinReadyNodes *int64 `json:"minReadyNodes,omitempty"`
+
+	// APIGroups that must be served by the cluster.
+	// +optional
+	APIGroups []string `json:"apiGroups,omitempty"`
+}
+
+// An ExistingClusterPoolSpec defines the desired state of an
+// ExistingClusterPool.
+type ExistingClusterPoolSpec struct {
+	// Selector selects the ExistingClusters in this pool. Pooled
+	// ExistingClusters should use the Retain reclaim policy; those using the
+	// Delete policy are deleted when the claim they are leased to is deleted.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// LeaseTTL is the maximum duration of a lease. A claim whose lease
+	// expires is deleted, returning its ExistingCluster to the pool. Leases
+	// do not expire if no TTL is specified.
+	// +optional
+	LeaseTTL *metav1.Duration `json:"leaseTTL,omitempty"`
+
+	// Requirements of every ExistingCluster leased from the pool. A claim
+	// may specify further requirements using annotations.
+	// +optional
+	Requirements *ClusterRequirements `json:"requirements,omitempty"`
+
+	// Scrub configures the scrubbing of ExistingClusters when they are
+	// returned to the pool. ExistingClusters are not scrubbed if no scrub
+	// policy is specified.
+	// +optional
+	Scrub *ClusterScrubPolicy `json:"scrub,omitempty"`
+}
+
+// An ObjectKind identifies a kind of object.
+type ObjectKind struct {
+	// APIVersion of the kind.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind name.
+	Kind string `json:"kind"`
+}
+
+// A ClusterScrubPolicy configures the scrubbing of ExistingClusters returned
+// to an ExistingClusterPool. The objects in an ExistingCluster's cluster are
+// recorded in the control plane when it is leased, and any namespace or
+// cluster scoped object created during the lease is deleted when it is
+// returned. The ExistingCluster may not be leased again until scrubbing
+// succeeds, which requires that the record of its objects exists.
+type ClusterScrubPolicy struct {
+	// ClusterScopedKinds are the kinds of cluster scoped objects that are
+	// scrubbed, in addition to namespaces. CustomResourceDefinitions,
+	// ClusterRoles, and ClusterRoleBindings are scrubbed if no kinds are
+	// specified.
+	// +optional
+	ClusterScopedKinds []ObjectKind `json:"clusterScopedKinds,omitempty"`
+
+	// SnapshotNamespace is the namespace of the control plane in which the
+	// objects that existed in an ExistingCluster's cluster when it was leased
+	// are recorded. Defaults to crossplane-system.
+	// +optional
+	SnapshotNamespace string `json:"snapshotNamespace,omitempty"`
+}
+
+// A ClusterLease records the lease of an ExistingCluster to a claim.
+type ClusterLease struct {
+	// ClusterName is the name of the leased ExistingCluster.
+	ClusterName string `json:"clusterName"`
+
+	// ClaimReference references the claim the ExistingCluster is leased to.
+	ClaimReference corev1.ObjectReference `json:"claimRef"`
+
+	// Time at which the lease started.
+	Time metav1.Time `json:"time"`
+
+	// ExpirationTime is the time at which the lease expires, if any.
+	// +optional
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// An ExistingClusterPoolStatus represents the observed state of an
+// ExistingClusterPool.
+type ExistingClusterPoolStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// Capacity is the number of ExistingClusters in the pool.
+	Capacity int64 `json:"capacity"`
+
+	// Available is the number of ExistingClusters that may be leased.
+	Available int64 `json:"available"`
+
+	// Leased is the number of ExistingClusters that are leased to claims.
+	Leased int64 `json:"leased"`
+
+	// Pending is the number of claims waiting for a lease.
+	Pending int64 `json:"pending"`
+
+	// Leases of ExistingClusters in the pool to claims.
+	// +optional
+	Leases []ClusterLease `json:"leases,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ExistingClusterPool leases ExistingClusters to KubernetesCluster claims,
+// one claim at a time. A claim requests a lease by referencing the pool as its
+// class.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="CAPACITY",type="integer",JSONPath=".status.capacity"
+// +kubebuilder:printcolumn:name="AVAILABLE",type="integer",JSONPath=".status.available"
+// +kubebuilder:printcolumn:name="LEASED",type="integer",JSONPath=".status.leased"
+// +kubebuilder:printcolumn:name="PENDING",type="integer",JSONPath=".status.pending"
+// +kubebuilder:printcolumn:name="LEASE-TTL",type="string",JSONPath=".spec.leaseTTL"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane}
+type ExistingClusterPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ExistingClusterPoolSpec   `json:"spec"`
+	Status ExistingClusterPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ExistingClusterPoolList contains a list of ExistingClusterPool items
+type ExistingClusterPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ExistingClusterPool `json:"items"`
+}
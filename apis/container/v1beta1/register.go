@@ -45,6 +45,24 @@ var (
 	ExistingClusterGroupVersionKind = SchemeGroupVersion.WithKind(ExistingClusterKind)
 )
 
+// ExistingClusterClass type metadata.
+var (
+	ExistingClusterClassKind             = reflect.TypeOf(ExistingClusterClass{}).Name()
+	ExistingClusterClassGroupKind        = schema.GroupKind{Group: Group, Kind: ExistingClusterClassKind}.String()
+	ExistingClusterClassKindAPIVersion   = ExistingClusterClassKind + "." + SchemeGroupVersion.String()
+	ExistingClusterClassGroupVersionKind = SchemeGroupVersion.WithKind(ExistingClusterClassKind)
+)
+
+// ExistingClusterPool type metadata.
+var (
+	ExistingClusterPoolKind             = reflect.TypeOf(ExistingClusterPool{}).Name()
+	ExistingClusterPoolGroupKind        = schema.GroupKind{Group: Group, Kind: ExistingClusterPoolKind}.String()
+	ExistingClusterPoolKindAPIVersion   = ExistingClusterPoolKind + "." + SchemeGroupVersion.String()
+	ExistingClusterPoolGroupVersionKind = SchemeGroupVersion.WithKind(ExistingClusterPoolKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&ExistingCluster{}, &ExistingClusterList{})
+	SchemeBuilder.Register(&ExistingClusterClass{}, &ExistingClusterClassList{})
+	SchemeBuilder.Register(&ExistingClusterPool{}, &ExistingClusterPoolList{})
 }
@@ -21,9 +21,107 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterFingerprint) DeepCopyInto(out *ClusterFingerprint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterFingerprint.
+func (in *ClusterFingerprint) DeepCopy() *ClusterFingerprint {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterFingerprint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterLease) DeepCopyInto(out *ClusterLease) {
+	*out = *in
+	out.ClaimReference = in.ClaimReference
+	in.Time.DeepCopyInto(&out.Time)
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterLease.
+func (in *ClusterLease) DeepCopy() *ClusterLease {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterLease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRegistration) DeepCopyInto(out *ClusterRegistration) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRegistration.
+func (in *ClusterRegistration) DeepCopy() *ClusterRegistration {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRegistration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterRequirements) DeepCopyInto(out *ClusterRequirements) {
+	*out = *in
+	if in.MinReadyNodes != nil {
+		in, out := &in.MinReadyNodes, &out.MinReadyNodes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterRequirements.
+func (in *ClusterRequirements) DeepCopy() *ClusterRequirements {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterRequirements)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterScrubPolicy) DeepCopyInto(out *ClusterScrubPolicy) {
+	*out = *in
+	if in.ClusterScopedKinds != nil {
+		in, out := &in.ClusterScopedKinds, &out.ClusterScopedKinds
+		*out = make([]ObjectKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterScrubPolicy.
+func (in *ClusterScrubPolicy) DeepCopy() *ClusterScrubPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterScrubPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExistingCluster) DeepCopyInto(out *ExistingCluster) {
 	*out = *in
@@ -51,6 +149,81 @@ func (in *ExistingCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterClass) DeepCopyInto(out *ExistingClusterClass) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.SpecTemplate.DeepCopyInto(&out.SpecTemplate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterClass.
+func (in *ExistingClusterClass) DeepCopy() *ExistingClusterClass {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExistingClusterClass) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterClassList) DeepCopyInto(out *ExistingClusterClassList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExistingClusterClass, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterClassList.
+func (in *ExistingClusterClassList) DeepCopy() *ExistingClusterClassList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterClassList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExistingClusterClassList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterClassSpecTemplate) DeepCopyInto(out *ExistingClusterClassSpecTemplate) {
+	*out = *in
+	in.ClassSpecTemplate.DeepCopyInto(&out.ClassSpecTemplate)
+	out.ForProvider = in.ForProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterClassSpecTemplate.
+func (in *ExistingClusterClassSpecTemplate) DeepCopy() *ExistingClusterClassSpecTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterClassSpecTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExistingClusterList) DeepCopyInto(out *ExistingClusterList) {
 	*out = *in
@@ -86,6 +259,30 @@ func (in *ExistingClusterList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExistingClusterObservation) DeepCopyInto(out *ExistingClusterObservation) {
 	*out = *in
+	if in.ClientCertificateExpiry != nil {
+		in, out := &in.ClientCertificateExpiry, &out.ClientCertificateExpiry
+		*out = (*in).DeepCopy()
+	}
+	if in.CreatedObjects != nil {
+		in, out := &in.CreatedObjects, &out.CreatedObjects
+		*out = make([]RemoteObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Registration != nil {
+		in, out := &in.Registration, &out.Registration
+		*out = new(ClusterRegistration)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Fingerprint != nil {
+		in, out := &in.Fingerprint, &out.Fingerprint
+		*out = new(ClusterFingerprint)
+		**out = **in
+	}
+	if in.APIGroups != nil {
+		in, out := &in.APIGroups, &out.APIGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterObservation.
@@ -113,6 +310,119 @@ func (in *ExistingClusterParameters) DeepCopy() *ExistingClusterParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterPool) DeepCopyInto(out *ExistingClusterPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterPool.
+func (in *ExistingClusterPool) DeepCopy() *ExistingClusterPool {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExistingClusterPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterPoolList) DeepCopyInto(out *ExistingClusterPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ExistingClusterPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterPoolList.
+func (in *ExistingClusterPoolList) DeepCopy() *ExistingClusterPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ExistingClusterPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterPoolSpec) DeepCopyInto(out *ExistingClusterPoolSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.LeaseTTL != nil {
+		in, out := &in.LeaseTTL, &out.LeaseTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Requirements != nil {
+		in, out := &in.Requirements, &out.Requirements
+		*out = new(ClusterRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scrub != nil {
+		in, out := &in.Scrub, &out.Scrub
+		*out = new(ClusterScrubPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterPoolSpec.
+func (in *ExistingClusterPoolSpec) DeepCopy() *ExistingClusterPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExistingClusterPoolStatus) DeepCopyInto(out *ExistingClusterPoolStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Leases != nil {
+		in, out := &in.Leases, &out.Leases
+		*out = make([]ClusterLease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterPoolStatus.
+func (in *ExistingClusterPoolStatus) DeepCopy() *ExistingClusterPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExistingClusterPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExistingClusterSpec) DeepCopyInto(out *ExistingClusterSpec) {
 	*out = *in
@@ -134,7 +444,7 @@ func (in *ExistingClusterSpec) DeepCopy() *ExistingClusterSpec {
 func (in *ExistingClusterStatus) DeepCopyInto(out *ExistingClusterStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	out.AtProvider = in.AtProvider
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExistingClusterStatus.
@@ -146,3 +456,33 @@ func (in *ExistingClusterStatus) DeepCopy() *ExistingClusterStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectKind) DeepCopyInto(out *ObjectKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectKind.
+func (in *ObjectKind) DeepCopy() *ObjectKind {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectReference) DeepCopyInto(out *RemoteObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectReference.
+func (in *RemoteObjectReference) DeepCopy() *RemoteObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -22,12 +22,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	containerv1beta1 "github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	remotev1alpha1 "github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
 )
 
 func init() {
 	// Register the types with the Scheme so the components can map objects to GroupVersionKinds and back
 	AddToSchemes = append(AddToSchemes,
 		containerv1beta1.SchemeBuilder.AddToScheme,
+		remotev1alpha1.SchemeBuilder.AddToScheme,
 		v1beta1.SchemeBuilder.AddToScheme,
 	)
 }
@@ -0,0 +1,109 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A CRDManifest holds the manifest of a CustomResourceDefinition.
+type CRDManifest struct {
+	// Manifest of the CustomResourceDefinition, in either the
+	// apiextensions.k8s.io/v1beta1 or apiextensions.k8s.io/v1 form. Only the
+	// fields specified by the manifest are managed; fields set by others are
+	// left untouched.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Manifest runtime.RawExtension `json:"manifest"`
+}
+
+// RemoteCRDBundleParameters define the desired state of a set of
+// CustomResourceDefinitions.
+type RemoteCRDBundleParameters struct {
+	// CustomResourceDefinitions to install or upgrade.
+	// +kubebuilder:validation:MinItems=1
+	CustomResourceDefinitions []CRDManifest `json:"customResourceDefinitions"`
+}
+
+// A CRDStatus is the observed state of a CustomResourceDefinition.
+type CRDStatus struct {
+	// Name of the CustomResourceDefinition.
+	Name string `json:"name"`
+
+	// Established is true if the CustomResourceDefinition's API is served.
+	Established bool `json:"established"`
+
+	// StoredVersions are the versions of the CustomResourceDefinition that
+	// have ever been persisted. A CustomResourceDefinition may not be changed
+	// to drop a stored version.
+	// +optional
+	StoredVersions []string `json:"storedVersions,omitempty"`
+}
+
+// RemoteCRDBundleObservation is the observed state of a set of
+// CustomResourceDefinitions.
+type RemoteCRDBundleObservation struct {
+	// CustomResourceDefinitions that exist and were created by the
+	// RemoteCRDBundle.
+	CustomResourceDefinitions []CRDStatus `json:"customResourceDefinitions,omitempty"`
+}
+
+// A RemoteCRDBundleSpec defines the desired state of a RemoteCRDBundle.
+type RemoteCRDBundleSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteCRDBundleParameters `json:"forProvider"`
+}
+
+// A RemoteCRDBundleStatus represents the observed state of a RemoteCRDBundle.
+type RemoteCRDBundleStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteCRDBundleObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteCRDBundle is a managed resource that represents a set of
+// CustomResourceDefinitions within the cluster represented by an
+// ExistingCluster. It is available once every CustomResourceDefinition is
+// established. Changes that would drop a version of a CustomResourceDefinition
+// that has been stored are refused. CustomResourceDefinitions that were not
+// created by the RemoteCRDBundle are never adopted, updated, or deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteCRDBundle struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteCRDBundleSpec   `json:"spec"`
+	Status RemoteCRDBundleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteCRDBundleList contains a list of RemoteCRDBundle items
+type RemoteCRDBundleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteCRDBundle `json:"items"`
+}
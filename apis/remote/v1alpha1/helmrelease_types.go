@@ -0,0 +1,164 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A ConfigMapKeySelector selects a key of a ConfigMap.
+type ConfigMapKeySelector struct {
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+
+	// Key of the ConfigMap. Keys of both its data and its binaryData are
+	// selected.
+	Key string `json:"key"`
+}
+
+// A ChartRepository is a Helm chart repository whose index is held by the
+// control plane.
+type ChartRepository struct {
+	// IndexRef selects the ConfigMap key that holds the repository's index,
+	// i.e. its index.yaml file.
+	IndexRef ConfigMapKeySelector `json:"indexRef"`
+
+	// URL of the repository. Relative chart URLs of the repository's index
+	// are resolved against it.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// Name of the chart.
+	Name string `json:"name"`
+
+	// Version of the chart. Version constraints such as ^1.2 are supported.
+	// The latest version is used if no version is specified.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// A ChartSource specifies where a Helm chart is loaded from. Exactly one source
+// must be specified.
+type ChartSource struct {
+	// ConfigMapRef selects a ConfigMap key that holds a chart archive, i.e. a
+	// gzipped tarball as produced by helm package.
+	// +optional
+	ConfigMapRef *ConfigMapKeySelector `json:"configMapRef,omitempty"`
+
+	// SecretRef selects a Secret key that holds a chart archive.
+	// +optional
+	SecretRef *runtimev1alpha1.SecretKeySelector `json:"secretRef,omitempty"`
+
+	// Repository from which the chart is downloaded.
+	// +optional
+	Repository *ChartRepository `json:"repository,omitempty"`
+}
+
+// RemoteHelmReleaseParameters define the desired state of a Helm release. The
+// release is named for the RemoteHelmRelease's external name.
+type RemoteHelmReleaseParameters struct {
+	// Chart of the release.
+	Chart ChartSource `json:"chart"`
+
+	// Namespace of the release. The namespace must exist.
+	Namespace string `json:"namespace"`
+
+	// Values of the release, as YAML.
+	// +optional
+	Values string `json:"values,omitempty"`
+
+	// ValuesFrom selects Secret keys that hold values of the release, as
+	// YAML. They are merged over the values specified inline, in order.
+	// +optional
+	ValuesFrom []runtimev1alpha1.SecretKeySelector `json:"valuesFrom,omitempty"`
+
+	// AdoptExisting allows an existing release that was not installed by
+	// this RemoteHelmRelease to be adopted, and thus upgraded and
+	// uninstalled by it. Existing releases are otherwise refused.
+	// +optional
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+}
+
+// RemoteHelmReleaseObservation is the observed state of a Helm release.
+type RemoteHelmReleaseObservation struct {
+	// Revision of the release.
+	Revision int `json:"revision,omitempty"`
+
+	// Status of the release's latest revision.
+	Status string `json:"status,omitempty"`
+
+	// ChartName is the name of the release's chart.
+	ChartName string `json:"chartName,omitempty"`
+
+	// ChartVersion is the version of the release's chart.
+	ChartVersion string `json:"chartVersion,omitempty"`
+
+	// AppVersion is the application version of the release's chart.
+	AppVersion string `json:"appVersion,omitempty"`
+
+	// Owned is true if the release was installed or adopted by this
+	// RemoteHelmRelease.
+	Owned bool `json:"owned,omitempty"`
+}
+
+// A RemoteHelmReleaseSpec defines the desired state of a RemoteHelmRelease.
+type RemoteHelmReleaseSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteHelmReleaseParameters `json:"forProvider"`
+}
+
+// A RemoteHelmReleaseStatus represents the observed state of a
+// RemoteHelmRelease.
+type RemoteHelmReleaseStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteHelmReleaseObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteHelmRelease is a managed resource that represents a Helm release
+// within the cluster represented by an ExistingCluster. An existing release of
+// the same name is only adopted if adoptExisting is set.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="CHART",type="string",JSONPath=".status.atProvider.chartName"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.chartVersion"
+// +kubebuilder:printcolumn:name="REVISION",type="integer",JSONPath=".status.atProvider.revision"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteHelmRelease struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteHelmReleaseSpec   `json:"spec"`
+	Status RemoteHelmReleaseStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteHelmReleaseList contains a list of RemoteHelmRelease items
+type RemoteHelmReleaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteHelmRelease `json:"items"`
+}
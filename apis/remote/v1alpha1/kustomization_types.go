@@ -0,0 +1,111 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A ConfigMapReference references a ConfigMap.
+type ConfigMapReference struct {
+	// Namespace of the ConfigMap.
+	Namespace string `json:"namespace"`
+
+	// Name of the ConfigMap.
+	Name string `json:"name"`
+}
+
+// KustomizationFiles are files of a kustomization held by a ConfigMap. Each
+// key of the ConfigMap is a file.
+type KustomizationFiles struct {
+	// ConfigMapRef references the ConfigMap that holds the files.
+	ConfigMapRef ConfigMapReference `json:"configMapRef"`
+
+	// Path of the directory in which the files are placed, relative to the
+	// root of the kustomization's files. Files are placed at the root if no
+	// path is specified.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// RemoteKustomizationParameters define the desired state of a kustomization.
+type RemoteKustomizationParameters struct {
+	// Files of the kustomization, and of any bases it references.
+	Files []KustomizationFiles `json:"files"`
+
+	// Path of the directory of the kustomization to render, relative to the
+	// root of the kustomization's files. The kustomization at the root is
+	// rendered if no path is specified.
+	// +optional
+	Path string `json:"path,omitempty"`
+}
+
+// RemoteKustomizationObservation is the observed state of a kustomization.
+type RemoteKustomizationObservation struct {
+	// Inventory of the objects that were applied when the kustomization was
+	// last rendered, in the order in which they were applied. Objects that
+	// disappear from the inventory are deleted.
+	Inventory []ObjectReference `json:"inventory,omitempty"`
+
+	// LastAppliedTime is the time at which the kustomization was last
+	// applied in full.
+	// +optional
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+}
+
+// A RemoteKustomizationSpec defines the desired state of a
+// RemoteKustomization.
+type RemoteKustomizationSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteKustomizationParameters `json:"forProvider"`
+}
+
+// A RemoteKustomizationStatus represents the observed state of a
+// RemoteKustomization.
+type RemoteKustomizationStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteKustomizationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteKustomization is a managed resource that represents the objects
+// rendered by a kustomization within the cluster represented by an
+// ExistingCluster. The objects are applied using server-side apply.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteKustomization struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteKustomizationSpec   `json:"spec"`
+	Status RemoteKustomizationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteKustomizationList contains a list of RemoteKustomization items
+type RemoteKustomizationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteKustomization `json:"items"`
+}
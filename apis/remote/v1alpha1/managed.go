@@ -0,0 +1,587 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Remote resources reference an ExistingCluster rather than a Provider, so
+// they do not embed the managed resource spec of crossplane-runtime and angryjet
+// cannot generate their resource.Managed method sets. Keep the methods below in
+// the order and form angryjet would generate them.
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GetBindingPhase of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteCRDBundle.
+func (mg *RemoteCRDBundle) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteHelmRelease.
+func (mg *RemoteHelmRelease) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteKustomization.
+func (mg *RemoteKustomization) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteKustomization.
+func (mg *RemoteKustomization) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteKustomization.
+func (mg *RemoteKustomization) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteKustomization.
+func (mg *RemoteKustomization) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteKustomization.
+func (mg *RemoteKustomization) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteKustomization.
+func (mg *RemoteKustomization) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteKustomization.
+func (mg *RemoteKustomization) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteKustomization.
+func (mg *RemoteKustomization) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteKustomization.
+func (mg *RemoteKustomization) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteKustomization.
+func (mg *RemoteKustomization) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteKustomization.
+func (mg *RemoteKustomization) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteKustomization.
+func (mg *RemoteKustomization) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteKustomization.
+func (mg *RemoteKustomization) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteKustomization.
+func (mg *RemoteKustomization) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteNamespace.
+func (mg *RemoteNamespace) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteNamespace.
+func (mg *RemoteNamespace) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteNamespace.
+func (mg *RemoteNamespace) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteNamespace.
+func (mg *RemoteNamespace) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteNamespace.
+func (mg *RemoteNamespace) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteNamespace.
+func (mg *RemoteNamespace) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteNamespace.
+func (mg *RemoteNamespace) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteNamespace.
+func (mg *RemoteNamespace) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteNamespace.
+func (mg *RemoteNamespace) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteNamespace.
+func (mg *RemoteNamespace) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteNamespace.
+func (mg *RemoteNamespace) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteNamespace.
+func (mg *RemoteNamespace) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteNamespace.
+func (mg *RemoteNamespace) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteNamespace.
+func (mg *RemoteNamespace) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteObject.
+func (mg *RemoteObject) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteObject.
+func (mg *RemoteObject) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteObject.
+func (mg *RemoteObject) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteObject.
+func (mg *RemoteObject) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteObject.
+func (mg *RemoteObject) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteObject.
+func (mg *RemoteObject) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteObject.
+func (mg *RemoteObject) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteObject.
+func (mg *RemoteObject) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteObject.
+func (mg *RemoteObject) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteObject.
+func (mg *RemoteObject) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteObject.
+func (mg *RemoteObject) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteObject.
+func (mg *RemoteObject) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteObject.
+func (mg *RemoteObject) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteObject.
+func (mg *RemoteObject) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteObjectImport.
+func (mg *RemoteObjectImport) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteSecretImport.
+func (mg *RemoteSecretImport) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
+
+// GetBindingPhase of this RemoteTenant.
+func (mg *RemoteTenant) GetBindingPhase() runtimev1alpha1.BindingPhase {
+	return mg.Status.GetBindingPhase()
+}
+
+// GetClaimReference of this RemoteTenant.
+func (mg *RemoteTenant) GetClaimReference() *corev1.ObjectReference {
+	return mg.Spec.ClaimReference
+}
+
+// GetClassReference of this RemoteTenant.
+func (mg *RemoteTenant) GetClassReference() *corev1.ObjectReference {
+	return mg.Spec.ClassReference
+}
+
+// GetClusterReference of this RemoteTenant.
+func (mg *RemoteTenant) GetClusterReference() *corev1.ObjectReference {
+	return mg.Spec.ClusterReference
+}
+
+// GetCondition of this RemoteTenant.
+func (mg *RemoteTenant) GetCondition(ct runtimev1alpha1.ConditionType) runtimev1alpha1.Condition {
+	return mg.Status.GetCondition(ct)
+}
+
+// GetReclaimPolicy of this RemoteTenant.
+func (mg *RemoteTenant) GetReclaimPolicy() runtimev1alpha1.ReclaimPolicy {
+	return mg.Spec.ReclaimPolicy
+}
+
+// GetWriteConnectionSecretToReference of this RemoteTenant.
+func (mg *RemoteTenant) GetWriteConnectionSecretToReference() *runtimev1alpha1.SecretReference {
+	return mg.Spec.WriteConnectionSecretToReference
+}
+
+// SetBindingPhase of this RemoteTenant.
+func (mg *RemoteTenant) SetBindingPhase(p runtimev1alpha1.BindingPhase) {
+	mg.Status.SetBindingPhase(p)
+}
+
+// SetClaimReference of this RemoteTenant.
+func (mg *RemoteTenant) SetClaimReference(r *corev1.ObjectReference) {
+	mg.Spec.ClaimReference = r
+}
+
+// SetClassReference of this RemoteTenant.
+func (mg *RemoteTenant) SetClassReference(r *corev1.ObjectReference) {
+	mg.Spec.ClassReference = r
+}
+
+// SetClusterReference of this RemoteTenant.
+func (mg *RemoteTenant) SetClusterReference(r *corev1.ObjectReference) {
+	mg.Spec.ClusterReference = r
+}
+
+// SetConditions of this RemoteTenant.
+func (mg *RemoteTenant) SetConditions(c ...runtimev1alpha1.Condition) {
+	mg.Status.SetConditions(c...)
+}
+
+// SetReclaimPolicy of this RemoteTenant.
+func (mg *RemoteTenant) SetReclaimPolicy(r runtimev1alpha1.ReclaimPolicy) {
+	mg.Spec.ReclaimPolicy = r
+}
+
+// SetWriteConnectionSecretToReference of this RemoteTenant.
+func (mg *RemoteTenant) SetWriteConnectionSecretToReference(r *runtimev1alpha1.SecretReference) {
+	mg.Spec.WriteConnectionSecretToReference = r
+}
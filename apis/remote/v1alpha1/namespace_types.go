@@ -0,0 +1,83 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemoteNamespaceParameters define the desired state of a namespace. The
+// namespace is named for the RemoteNamespace's external name.
+type RemoteNamespaceParameters struct {
+	// Labels of the namespace. Labels that are not specified are left
+	// untouched.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the namespace. Annotations that are not specified are
+	// left untouched.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// RemoteNamespaceObservation is the observed state of a namespace.
+type RemoteNamespaceObservation struct {
+	// Phase of the namespace.
+	Phase string `json:"phase,omitempty"`
+}
+
+// A RemoteNamespaceSpec defines the desired state of a RemoteNamespace.
+type RemoteNamespaceSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteNamespaceParameters `json:"forProvider,omitempty"`
+}
+
+// A RemoteNamespaceStatus represents the observed state of a RemoteNamespace.
+type RemoteNamespaceStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteNamespaceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteNamespace is a managed resource that represents a namespace within
+// the cluster represented by an ExistingCluster. An existing namespace that was
+// not created by the RemoteNamespace is never adopted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.atProvider.phase"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteNamespace struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteNamespaceSpec   `json:"spec"`
+	Status RemoteNamespaceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteNamespaceList contains a list of RemoteNamespace items
+type RemoteNamespaceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteNamespace `json:"items"`
+}
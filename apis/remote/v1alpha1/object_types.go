@@ -0,0 +1,149 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// A ReadinessCheckType determines how the readiness of an object is checked.
+type ReadinessCheckType string
+
+// Readiness check types.
+const (
+	// ReadinessCheckExists considers an object ready once it exists.
+	ReadinessCheckExists ReadinessCheckType = "Exists"
+
+	// ReadinessCheckCondition considers an object ready once its status has
+	// a condition of the specified type and status.
+	ReadinessCheckCondition ReadinessCheckType = "Condition"
+
+	// ReadinessCheckFieldValue considers an object ready once the field at
+	// the specified JSONPath has the specified value.
+	ReadinessCheckFieldValue ReadinessCheckType = "FieldValue"
+)
+
+// A ReadinessCheck determines when an object is considered ready.
+type ReadinessCheck struct {
+	// Type of readiness check. The Exists check is used when no type is
+	// specified.
+	// +optional
+	// +kubebuilder:validation:Enum=Exists;Condition;FieldValue
+	Type ReadinessCheckType `json:"type,omitempty"`
+
+	// ConditionType is the type of the status condition that must be present
+	// for a Condition check, for example Available.
+	// +optional
+	ConditionType string `json:"conditionType,omitempty"`
+
+	// ConditionStatus is the status the condition must have for a Condition
+	// check. It defaults to True.
+	// +optional
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	ConditionStatus corev1.ConditionStatus `json:"conditionStatus,omitempty"`
+
+	// FieldPath is the JSONPath of the field to check for a FieldValue
+	// check, for example {.status.phase}.
+	// +optional
+	FieldPath string `json:"fieldPath,omitempty"`
+
+	// Value the field must have for a FieldValue check.
+	// +optional
+	Value string `json:"value,omitempty"`
+}
+
+// RemoteObjectParameters define the desired state of an arbitrary object.
+type RemoteObjectParameters struct {
+	// Manifest of the object. It must specify the object's apiVersion and
+	// kind, and its namespace if it is namespaced. The object is named for the
+	// RemoteObject's external name if the manifest does not name it. Only the
+	// fields specified by the manifest are managed; fields set by others are
+	// left untouched.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:EmbeddedResource
+	Manifest runtime.RawExtension `json:"manifest"`
+
+	// Readiness determines when the object is considered ready, and thus
+	// when the RemoteObject is reported as available. An object is ready
+	// once it exists if no readiness check is specified.
+	// +optional
+	Readiness *ReadinessCheck `json:"readiness,omitempty"`
+}
+
+// RemoteObjectObservation is the observed state of an arbitrary object.
+type RemoteObjectObservation struct {
+	// UID of the object.
+	UID string `json:"uid,omitempty"`
+
+	// ResourceVersion of the object when it was last observed.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Ready is true if the object passed its readiness check when it was
+	// last observed.
+	Ready bool `json:"ready,omitempty"`
+
+	// Object references the object that was last applied. It is deleted if
+	// the manifest is changed to describe a different object.
+	// +optional
+	Object *ObjectReference `json:"object,omitempty"`
+}
+
+// A RemoteObjectSpec defines the desired state of a RemoteObject.
+type RemoteObjectSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteObjectParameters `json:"forProvider"`
+}
+
+// A RemoteObjectStatus represents the observed state of a RemoteObject.
+type RemoteObjectStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteObjectObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteObject is a managed resource that represents an arbitrary object
+// within the cluster represented by an ExistingCluster. The object is applied
+// using server-side apply. An existing object that was not created by the
+// RemoteObject is never adopted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.forProvider.manifest.kind"
+// +kubebuilder:printcolumn:name="READY",type="boolean",JSONPath=".status.atProvider.ready"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteObject struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteObjectSpec   `json:"spec"`
+	Status RemoteObjectStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteObjectList contains a list of RemoteObject items
+type RemoteObjectList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteObject `json:"items"`
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// An ObjectReference identifies an object within a cluster.
+type ObjectReference struct {
+	// APIVersion of the referenced object.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the referenced object.
+	Kind string `json:"kind"`
+
+	// Namespace of the referenced object, if it is namespaced.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name of the referenced object.
+	Name string `json:"name"`
+}
+
+// An ImportedField is a field of an object that is published by a
+// RemoteObjectImport.
+type ImportedField struct {
+	// Name under which the field's value is published, both in the
+	// RemoteObjectImport's status and in its connection secret.
+	Name string `json:"name"`
+
+	// FieldPath is the JSONPath of the field, for example
+	// {.status.loadBalancer.ingress[0].ip}.
+	FieldPath string `json:"fieldPath"`
+
+	// Base64 indicates that the field's value is base64 encoded, as are the
+	// values of a Secret's data. The value is decoded before it is published.
+	// +optional
+	Base64 bool `json:"base64,omitempty"`
+
+	// Sensitive fields are published only to the connection secret, and not
+	// to the RemoteObjectImport's status.
+	// +optional
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// RemoteObjectImportParameters define the object to import fields from.
+type RemoteObjectImportParameters struct {
+	// ObjectReference identifies the object to import fields from.
+	ObjectReference ObjectReference `json:"objectRef"`
+
+	// Fields of the object to import.
+	Fields []ImportedField `json:"fields"`
+}
+
+// RemoteObjectImportObservation is the observed state of an imported object.
+type RemoteObjectImportObservation struct {
+	// UID of the object.
+	UID string `json:"uid,omitempty"`
+
+	// ResourceVersion of the object when it was last observed.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Fields of the object that are not sensitive, by name. Fields the
+	// object does not have are omitted.
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// A RemoteObjectImportSpec defines the desired state of a RemoteObjectImport.
+// Its reclaim policy has no effect; the object is never written to.
+type RemoteObjectImportSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteObjectImportParameters `json:"forProvider"`
+}
+
+// A RemoteObjectImportStatus represents the observed state of a
+// RemoteObjectImport.
+type RemoteObjectImportStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteObjectImportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteObjectImport is a managed resource that imports fields of an object
+// within the cluster represented by an ExistingCluster. The object is observed
+// but never created, updated, or deleted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.forProvider.objectRef.kind"
+// +kubebuilder:printcolumn:name="OBJECT",type="string",JSONPath=".spec.forProvider.objectRef.name"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteObjectImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteObjectImportSpec   `json:"spec"`
+	Status RemoteObjectImportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteObjectImportList contains a list of RemoteObjectImport items
+type RemoteObjectImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteObjectImport `json:"items"`
+}
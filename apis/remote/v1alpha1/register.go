@@ -0,0 +1,122 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "remote.dev.crossplane.io"
+	Version = "v1alpha1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// RemoteNamespace type metadata.
+var (
+	RemoteNamespaceKind             = reflect.TypeOf(RemoteNamespace{}).Name()
+	RemoteNamespaceGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteNamespaceKind}.String()
+	RemoteNamespaceKindAPIVersion   = RemoteNamespaceKind + "." + SchemeGroupVersion.String()
+	RemoteNamespaceGroupVersionKind = SchemeGroupVersion.WithKind(RemoteNamespaceKind)
+)
+
+// RemoteObject type metadata.
+var (
+	RemoteObjectKind             = reflect.TypeOf(RemoteObject{}).Name()
+	RemoteObjectGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteObjectKind}.String()
+	RemoteObjectKindAPIVersion   = RemoteObjectKind + "." + SchemeGroupVersion.String()
+	RemoteObjectGroupVersionKind = SchemeGroupVersion.WithKind(RemoteObjectKind)
+)
+
+// RemoteObjectImport type metadata.
+var (
+	RemoteObjectImportKind             = reflect.TypeOf(RemoteObjectImport{}).Name()
+	RemoteObjectImportGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteObjectImportKind}.String()
+	RemoteObjectImportKindAPIVersion   = RemoteObjectImportKind + "." + SchemeGroupVersion.String()
+	RemoteObjectImportGroupVersionKind = SchemeGroupVersion.WithKind(RemoteObjectImportKind)
+)
+
+// RemoteHelmRelease type metadata.
+var (
+	RemoteHelmReleaseKind             = reflect.TypeOf(RemoteHelmRelease{}).Name()
+	RemoteHelmReleaseGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteHelmReleaseKind}.String()
+	RemoteHelmReleaseKindAPIVersion   = RemoteHelmReleaseKind + "." + SchemeGroupVersion.String()
+	RemoteHelmReleaseGroupVersionKind = SchemeGroupVersion.WithKind(RemoteHelmReleaseKind)
+)
+
+// RemoteKustomization type metadata.
+var (
+	RemoteKustomizationKind             = reflect.TypeOf(RemoteKustomization{}).Name()
+	RemoteKustomizationGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteKustomizationKind}.String()
+	RemoteKustomizationKindAPIVersion   = RemoteKustomizationKind + "." + SchemeGroupVersion.String()
+	RemoteKustomizationGroupVersionKind = SchemeGroupVersion.WithKind(RemoteKustomizationKind)
+)
+
+// RemoteSecretSync type metadata.
+var (
+	RemoteSecretSyncKind             = reflect.TypeOf(RemoteSecretSync{}).Name()
+	RemoteSecretSyncGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteSecretSyncKind}.String()
+	RemoteSecretSyncKindAPIVersion   = RemoteSecretSyncKind + "." + SchemeGroupVersion.String()
+	RemoteSecretSyncGroupVersionKind = SchemeGroupVersion.WithKind(RemoteSecretSyncKind)
+)
+
+// RemoteSecretImport type metadata.
+var (
+	RemoteSecretImportKind             = reflect.TypeOf(RemoteSecretImport{}).Name()
+	RemoteSecretImportGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteSecretImportKind}.String()
+	RemoteSecretImportKindAPIVersion   = RemoteSecretImportKind + "." + SchemeGroupVersion.String()
+	RemoteSecretImportGroupVersionKind = SchemeGroupVersion.WithKind(RemoteSecretImportKind)
+)
+
+// RemoteCRDBundle type metadata.
+var (
+	RemoteCRDBundleKind             = reflect.TypeOf(RemoteCRDBundle{}).Name()
+	RemoteCRDBundleGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteCRDBundleKind}.String()
+	RemoteCRDBundleKindAPIVersion   = RemoteCRDBundleKind + "." + SchemeGroupVersion.String()
+	RemoteCRDBundleGroupVersionKind = SchemeGroupVersion.WithKind(RemoteCRDBundleKind)
+)
+
+// RemoteTenant type metadata.
+var (
+	RemoteTenantKind             = reflect.TypeOf(RemoteTenant{}).Name()
+	RemoteTenantGroupKind        = schema.GroupKind{Group: Group, Kind: RemoteTenantKind}.String()
+	RemoteTenantKindAPIVersion   = RemoteTenantKind + "." + SchemeGroupVersion.String()
+	RemoteTenantGroupVersionKind = SchemeGroupVersion.WithKind(RemoteTenantKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&RemoteNamespace{}, &RemoteNamespaceList{})
+	SchemeBuilder.Register(&RemoteObject{}, &RemoteObjectList{})
+	SchemeBuilder.Register(&RemoteObjectImport{}, &RemoteObjectImportList{})
+	SchemeBuilder.Register(&RemoteHelmRelease{}, &RemoteHelmReleaseList{})
+	SchemeBuilder.Register(&RemoteKustomization{}, &RemoteKustomizationList{})
+	SchemeBuilder.Register(&RemoteSecretSync{}, &RemoteSecretSyncList{})
+	SchemeBuilder.Register(&RemoteSecretImport{}, &RemoteSecretImportList{})
+	SchemeBuilder.Register(&RemoteCRDBundle{}, &RemoteCRDBundleList{})
+	SchemeBuilder.Register(&RemoteTenant{}, &RemoteTenantList{})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// An ImportedKey is a key of a Secret that is imported by a RemoteSecretImport.
+type ImportedKey struct {
+	// Key of the Secret in the cluster.
+	Key string `json:"key"`
+
+	// ToKey is the key of the connection secret to which the key is
+	// imported. Keys are imported to the same key if none is specified.
+	// +optional
+	ToKey string `json:"toKey,omitempty"`
+}
+
+// RemoteSecretImportParameters define the Secret to import.
+type RemoteSecretImportParameters struct {
+	// SecretReference references the Secret to import.
+	SecretReference runtimev1alpha1.SecretReference `json:"secretRef"`
+
+	// Keys of the Secret to import. All keys are imported if none are
+	// specified.
+	// +optional
+	Keys []ImportedKey `json:"keys,omitempty"`
+
+	// DeleteOnRemoval causes the connection secret to be deleted when the
+	// Secret is removed from the cluster. Otherwise the connection secret
+	// retains the keys that were last imported.
+	// +optional
+	DeleteOnRemoval bool `json:"deleteOnRemoval,omitempty"`
+}
+
+// RemoteSecretImportObservation is the observed state of an imported Secret.
+type RemoteSecretImportObservation struct {
+	// ResourceVersion of the Secret when it was last imported.
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// ImportedKeys are the keys of the connection secret that were last
+	// imported.
+	ImportedKeys []string `json:"importedKeys,omitempty"`
+}
+
+// A RemoteSecretImportSpec defines the desired state of a RemoteSecretImport.
+// Its reclaim policy has no effect; the Secret is never written to.
+type RemoteSecretImportSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteSecretImportParameters `json:"forProvider"`
+}
+
+// A RemoteSecretImportStatus represents the observed state of a
+// RemoteSecretImport.
+type RemoteSecretImportStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteSecretImportObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteSecretImport is a managed resource that mirrors the keys of a Secret
+// within the cluster represented by an ExistingCluster to its connection
+// secret. The connection secret is updated whenever the Secret changes.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="SECRET",type="string",JSONPath=".spec.forProvider.secretRef.name"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteSecretImport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteSecretImportSpec   `json:"spec"`
+	Status RemoteSecretImportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteSecretImportList contains a list of RemoteSecretImport items
+type RemoteSecretImportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteSecretImport `json:"items"`
+}
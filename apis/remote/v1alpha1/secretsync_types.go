@@ -0,0 +1,144 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// A SyncSourceKind is a kind of object that may be synced.
+type SyncSourceKind string
+
+// Kinds of object that may be synced.
+const (
+	SyncSourceSecret    SyncSourceKind = "Secret"
+	SyncSourceConfigMap SyncSourceKind = "ConfigMap"
+)
+
+// A SyncSource is a Secret or ConfigMap of the control plane.
+type SyncSource struct {
+	// Kind of the source.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind SyncSourceKind `json:"kind"`
+
+	// Namespace of the source.
+	Namespace string `json:"namespace"`
+
+	// Name of the source.
+	Name string `json:"name"`
+}
+
+// A RemoteSecretSyncSpec defines the desired state of a RemoteSecretSync.
+type RemoteSecretSyncSpec struct {
+	// Source is the Secret or ConfigMap to copy.
+	Source SyncSource `json:"source"`
+
+	// ClusterReferences reference the ExistingClusters whose clusters the
+	// source is copied to.
+	// +optional
+	ClusterReferences []corev1.ObjectReference `json:"clusterRefs,omitempty"`
+
+	// ClusterSelector selects ExistingClusters whose clusters the source is
+	// copied to, in addition to those that are referenced.
+	// +optional
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+
+	// Namespace to which the source is copied in each cluster. The namespace
+	// must exist.
+	Namespace string `json:"namespace"`
+
+	// Name of each copy of the source. Copies are named for the source if no
+	// name is specified.
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// A ClusterSync records the sync of a source to the cluster represented by an
+// ExistingCluster.
+type ClusterSync struct {
+	// ClusterName is the name of the ExistingCluster.
+	ClusterName string `json:"clusterName"`
+
+	// ResourceVersion of the source when it was last synced to the cluster.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// ObservedGeneration of the RemoteSecretSync when the source was last
+	// synced to the cluster.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Copy references the copy of the source that was last synced to the
+	// cluster. The copy is deleted if the RemoteSecretSync is changed to
+	// copy the source elsewhere.
+	// +optional
+	Copy *ObjectReference `json:"copy,omitempty"`
+
+	// Time at which the source was last synced to the cluster.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// Error encountered when the source was last synced to the cluster, if
+	// any.
+	// +optional
+	Error string `json:"error,omitempty"`
+}
+
+// A RemoteSecretSyncStatus represents the observed state of a
+// RemoteSecretSync.
+type RemoteSecretSyncStatus struct {
+	runtimev1alpha1.ConditionedStatus `json:",inline"`
+
+	// ResourceVersion of the source when it was last observed.
+	// +optional
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+
+	// Clusters to which the source is synced.
+	// +optional
+	Clusters []ClusterSync `json:"clusters,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteSecretSync copies a Secret or ConfigMap of the control plane to the
+// clusters represented by one or more ExistingClusters, and copies it again
+// whenever it changes. Copies are deleted when the RemoteSecretSync is
+// deleted, and when a cluster is no longer targeted.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.source.kind"
+// +kubebuilder:printcolumn:name="SOURCE",type="string",JSONPath=".spec.source.name"
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,remote}
+type RemoteSecretSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteSecretSyncSpec   `json:"spec"`
+	Status RemoteSecretSyncStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteSecretSyncList contains a list of RemoteSecretSync items
+type RemoteSecretSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteSecretSync `json:"items"`
+}
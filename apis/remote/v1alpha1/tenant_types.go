@@ -0,0 +1,137 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TenantIsolation configures the NetworkPolicy of a tenant.
+type TenantIsolation struct {
+	// AllowFromNamespaces selects namespaces whose pods may send traffic to
+	// the tenant's pods, in addition to the tenant's own namespace.
+	// +optional
+	AllowFromNamespaces *metav1.LabelSelector `json:"allowFromNamespaces,omitempty"`
+}
+
+// A TenantRoleBinding grants a ClusterRole to subjects within the namespace of
+// a tenant.
+type TenantRoleBinding struct {
+	// Name of the RoleBinding. The name tenant-admin is reserved for the
+	// RoleBinding of the tenant's admin ServiceAccount.
+	Name string `json:"name"`
+
+	// ClusterRole to grant within the tenant's namespace. The ClusterRole of
+	// an existing RoleBinding cannot be changed.
+	ClusterRole string `json:"clusterRole"`
+
+	// Subjects to which the ClusterRole is granted.
+	// +kubebuilder:validation:MinItems=1
+	Subjects []rbacv1.Subject `json:"subjects"`
+}
+
+// RemoteTenantParameters define the desired state of a tenant. The tenant's
+// namespace is named for the RemoteTenant's external name.
+type RemoteTenantParameters struct {
+	// Labels of the tenant's namespace. Labels that are not specified are
+	// left untouched.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Annotations of the tenant's namespace. Annotations that are not
+	// specified are left untouched.
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+
+	// Quota limits the total resources consumed within the tenant's
+	// namespace. No ResourceQuota is created if it is not specified.
+	// +optional
+	Quota corev1.ResourceList `json:"quota,omitempty"`
+
+	// Limits constrain the resources of each object within the tenant's
+	// namespace. No LimitRange is created if none are specified.
+	// +optional
+	Limits []corev1.LimitRangeItem `json:"limits,omitempty"`
+
+	// Isolation restricts the tenant's pods to receiving traffic from within
+	// the tenant's namespace. No NetworkPolicy is created if it is not
+	// specified.
+	// +optional
+	Isolation *TenantIsolation `json:"isolation,omitempty"`
+
+	// RoleBindings within the tenant's namespace.
+	// +optional
+	RoleBindings []TenantRoleBinding `json:"roleBindings,omitempty"`
+}
+
+// RemoteTenantObservation is the observed state of a tenant.
+type RemoteTenantObservation struct {
+	// Phase of the tenant's namespace.
+	Phase string `json:"phase,omitempty"`
+
+	// Quota of the tenant's namespace, and its usage.
+	Quota *corev1.ResourceQuotaStatus `json:"quota,omitempty"`
+
+	// Inventory of the objects that make up the tenant.
+	Inventory []ObjectReference `json:"inventory,omitempty"`
+}
+
+// A RemoteTenantSpec defines the desired state of a RemoteTenant.
+type RemoteTenantSpec struct {
+	RemoteResourceSpec `json:",inline"`
+	ForProvider        RemoteTenantParameters `json:"forProvider,omitempty"`
+}
+
+// A RemoteTenantStatus represents the observed state of a RemoteTenant.
+type RemoteTenantStatus struct {
+	runtimev1alpha1.ResourceStatus `json:",inline"`
+	AtProvider                     RemoteTenantObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RemoteTenant is a managed resource that represents a tenant of the cluster
+// represented by an ExistingCluster. A tenant consists of a namespace and its
+// ResourceQuota, LimitRange, NetworkPolicy, and RoleBindings. The connection
+// secret of a RemoteTenant contains a kubeconfig that administers the tenant's
+// namespace.
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.bindingPhase"
+// +kubebuilder:printcolumn:name="PHASE",type="string",JSONPath=".status.atProvider.phase"
+// +kubebuilder:printcolumn:name="CLUSTER",type="string",JSONPath=".spec.clusterRef.name"
+// +kubebuilder:printcolumn:name="RECLAIM-POLICY",type="string",JSONPath=".spec.reclaimPolicy"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,remote}
+type RemoteTenant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RemoteTenantSpec   `json:"spec"`
+	Status RemoteTenantStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RemoteTenantList contains a list of RemoteTenant items
+type RemoteTenantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RemoteTenant `json:"items"`
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// AnnotationKeyOwner is set to the UID of the managed resource that created
+// an object within the cluster represented by an ExistingCluster. A managed
+// resource neither adopts nor deletes an object that lacks this annotation and
+// the app.kubernetes.io/managed-by label. An existing object may be adopted by
+// setting both.
+const AnnotationKeyOwner = "remote.dev.crossplane.io/owner-uid"
+
+// A RemoteResourceSpec defines the desired state of a managed resource within
+// the cluster represented by an ExistingCluster. It differs from the managed
+// resource spec of crossplane-runtime in that the ExistingCluster's Provider
+// is used, rather than a Provider of the resource's own.
+type RemoteResourceSpec struct {
+	// ClusterReference references the ExistingCluster that represents the
+	// cluster in which this managed resource exists. The ExistingCluster's
+	// Provider is used to connect to the cluster.
+	ClusterReference *corev1.ObjectReference `json:"clusterRef"`
+
+	// WriteConnectionSecretToReference specifies the namespace and name of a
+	// Secret to which any connection details for this managed resource should
+	// be written.
+	// +optional
+	WriteConnectionSecretToReference *runtimev1alpha1.SecretReference `json:"writeConnectionSecretToRef,omitempty"`
+
+	// ClaimReference specifies the resource claim to which this managed
+	// resource will be bound.
+	// +optional
+	ClaimReference *corev1.ObjectReference `json:"claimRef,omitempty"`
+
+	// ClassReference specifies the resource class that was used to
+	// dynamically provision this managed resource, if any.
+	// +optional
+	ClassReference *corev1.ObjectReference `json:"classRef,omitempty"`
+
+	// ReclaimPolicy specifies what will happen to the resource within the
+	// cluster when this managed resource is deleted. The "Delete" policy
+	// causes it to be deleted. The "Retain" policy causes it to be retained.
+	// The "Retain" policy is used when no policy is specified.
+	// +optional
+	// +kubebuilder:validation:Enum=Retain;Delete
+	ReclaimPolicy runtimev1alpha1.ReclaimPolicy `json:"reclaimPolicy,omitempty"`
+}
@@ -0,0 +1,1556 @@
+// +build !ignore_autogenerated
+
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	corev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDManifest) DeepCopyInto(out *CRDManifest) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDManifest.
+func (in *CRDManifest) DeepCopy() *CRDManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRDStatus) DeepCopyInto(out *CRDStatus) {
+	*out = *in
+	if in.StoredVersions != nil {
+		in, out := &in.StoredVersions, &out.StoredVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRDStatus.
+func (in *CRDStatus) DeepCopy() *CRDStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRDStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartRepository) DeepCopyInto(out *ChartRepository) {
+	*out = *in
+	out.IndexRef = in.IndexRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartRepository.
+func (in *ChartRepository) DeepCopy() *ChartRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChartSource) DeepCopyInto(out *ChartSource) {
+	*out = *in
+	if in.ConfigMapRef != nil {
+		in, out := &in.ConfigMapRef, &out.ConfigMapRef
+		*out = new(ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(corev1alpha1.SecretKeySelector)
+		**out = **in
+	}
+	if in.Repository != nil {
+		in, out := &in.Repository, &out.Repository
+		*out = new(ChartRepository)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChartSource.
+func (in *ChartSource) DeepCopy() *ChartSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ChartSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterSync) DeepCopyInto(out *ClusterSync) {
+	*out = *in
+	if in.Copy != nil {
+		in, out := &in.Copy, &out.Copy
+		*out = new(ObjectReference)
+		**out = **in
+	}
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterSync.
+func (in *ClusterSync) DeepCopy() *ClusterSync {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeySelector) DeepCopyInto(out *ConfigMapKeySelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeySelector.
+func (in *ConfigMapKeySelector) DeepCopy() *ConfigMapKeySelector {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeySelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportedField) DeepCopyInto(out *ImportedField) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedField.
+func (in *ImportedField) DeepCopy() *ImportedField {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportedField)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImportedKey) DeepCopyInto(out *ImportedKey) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImportedKey.
+func (in *ImportedKey) DeepCopy() *ImportedKey {
+	if in == nil {
+		return nil
+	}
+	out := new(ImportedKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizationFiles) DeepCopyInto(out *KustomizationFiles) {
+	*out = *in
+	out.ConfigMapRef = in.ConfigMapRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizationFiles.
+func (in *KustomizationFiles) DeepCopy() *KustomizationFiles {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizationFiles)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReadinessCheck) DeepCopyInto(out *ReadinessCheck) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReadinessCheck.
+func (in *ReadinessCheck) DeepCopy() *ReadinessCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(ReadinessCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundle) DeepCopyInto(out *RemoteCRDBundle) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundle.
+func (in *RemoteCRDBundle) DeepCopy() *RemoteCRDBundle {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundle)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteCRDBundle) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundleList) DeepCopyInto(out *RemoteCRDBundleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteCRDBundle, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundleList.
+func (in *RemoteCRDBundleList) DeepCopy() *RemoteCRDBundleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteCRDBundleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundleObservation) DeepCopyInto(out *RemoteCRDBundleObservation) {
+	*out = *in
+	if in.CustomResourceDefinitions != nil {
+		in, out := &in.CustomResourceDefinitions, &out.CustomResourceDefinitions
+		*out = make([]CRDStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundleObservation.
+func (in *RemoteCRDBundleObservation) DeepCopy() *RemoteCRDBundleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundleParameters) DeepCopyInto(out *RemoteCRDBundleParameters) {
+	*out = *in
+	if in.CustomResourceDefinitions != nil {
+		in, out := &in.CustomResourceDefinitions, &out.CustomResourceDefinitions
+		*out = make([]CRDManifest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundleParameters.
+func (in *RemoteCRDBundleParameters) DeepCopy() *RemoteCRDBundleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundleSpec) DeepCopyInto(out *RemoteCRDBundleSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundleSpec.
+func (in *RemoteCRDBundleSpec) DeepCopy() *RemoteCRDBundleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteCRDBundleStatus) DeepCopyInto(out *RemoteCRDBundleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteCRDBundleStatus.
+func (in *RemoteCRDBundleStatus) DeepCopy() *RemoteCRDBundleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteCRDBundleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmRelease) DeepCopyInto(out *RemoteHelmRelease) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmRelease.
+func (in *RemoteHelmRelease) DeepCopy() *RemoteHelmRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteHelmRelease) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmReleaseList) DeepCopyInto(out *RemoteHelmReleaseList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteHelmRelease, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmReleaseList.
+func (in *RemoteHelmReleaseList) DeepCopy() *RemoteHelmReleaseList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmReleaseList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteHelmReleaseList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmReleaseObservation) DeepCopyInto(out *RemoteHelmReleaseObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmReleaseObservation.
+func (in *RemoteHelmReleaseObservation) DeepCopy() *RemoteHelmReleaseObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmReleaseObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmReleaseParameters) DeepCopyInto(out *RemoteHelmReleaseParameters) {
+	*out = *in
+	in.Chart.DeepCopyInto(&out.Chart)
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]corev1alpha1.SecretKeySelector, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmReleaseParameters.
+func (in *RemoteHelmReleaseParameters) DeepCopy() *RemoteHelmReleaseParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmReleaseParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmReleaseSpec) DeepCopyInto(out *RemoteHelmReleaseSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmReleaseSpec.
+func (in *RemoteHelmReleaseSpec) DeepCopy() *RemoteHelmReleaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmReleaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteHelmReleaseStatus) DeepCopyInto(out *RemoteHelmReleaseStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteHelmReleaseStatus.
+func (in *RemoteHelmReleaseStatus) DeepCopy() *RemoteHelmReleaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteHelmReleaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomization) DeepCopyInto(out *RemoteKustomization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomization.
+func (in *RemoteKustomization) DeepCopy() *RemoteKustomization {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteKustomization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomizationList) DeepCopyInto(out *RemoteKustomizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteKustomization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomizationList.
+func (in *RemoteKustomizationList) DeepCopy() *RemoteKustomizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteKustomizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomizationObservation) DeepCopyInto(out *RemoteKustomizationObservation) {
+	*out = *in
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomizationObservation.
+func (in *RemoteKustomizationObservation) DeepCopy() *RemoteKustomizationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomizationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomizationParameters) DeepCopyInto(out *RemoteKustomizationParameters) {
+	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]KustomizationFiles, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomizationParameters.
+func (in *RemoteKustomizationParameters) DeepCopy() *RemoteKustomizationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomizationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomizationSpec) DeepCopyInto(out *RemoteKustomizationSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomizationSpec.
+func (in *RemoteKustomizationSpec) DeepCopy() *RemoteKustomizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteKustomizationStatus) DeepCopyInto(out *RemoteKustomizationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteKustomizationStatus.
+func (in *RemoteKustomizationStatus) DeepCopy() *RemoteKustomizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteKustomizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespace) DeepCopyInto(out *RemoteNamespace) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespace.
+func (in *RemoteNamespace) DeepCopy() *RemoteNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteNamespace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespaceList) DeepCopyInto(out *RemoteNamespaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteNamespace, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespaceList.
+func (in *RemoteNamespaceList) DeepCopy() *RemoteNamespaceList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespaceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteNamespaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespaceObservation) DeepCopyInto(out *RemoteNamespaceObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespaceObservation.
+func (in *RemoteNamespaceObservation) DeepCopy() *RemoteNamespaceObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespaceObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespaceParameters) DeepCopyInto(out *RemoteNamespaceParameters) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespaceParameters.
+func (in *RemoteNamespaceParameters) DeepCopy() *RemoteNamespaceParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespaceParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespaceSpec) DeepCopyInto(out *RemoteNamespaceSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespaceSpec.
+func (in *RemoteNamespaceSpec) DeepCopy() *RemoteNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteNamespaceStatus) DeepCopyInto(out *RemoteNamespaceStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteNamespaceStatus.
+func (in *RemoteNamespaceStatus) DeepCopy() *RemoteNamespaceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteNamespaceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObject) DeepCopyInto(out *RemoteObject) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObject.
+func (in *RemoteObject) DeepCopy() *RemoteObject {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteObject) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImport) DeepCopyInto(out *RemoteObjectImport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImport.
+func (in *RemoteObjectImport) DeepCopy() *RemoteObjectImport {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteObjectImport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImportList) DeepCopyInto(out *RemoteObjectImportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteObjectImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImportList.
+func (in *RemoteObjectImportList) DeepCopy() *RemoteObjectImportList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteObjectImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImportObservation) DeepCopyInto(out *RemoteObjectImportObservation) {
+	*out = *in
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImportObservation.
+func (in *RemoteObjectImportObservation) DeepCopy() *RemoteObjectImportObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImportObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImportParameters) DeepCopyInto(out *RemoteObjectImportParameters) {
+	*out = *in
+	out.ObjectReference = in.ObjectReference
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]ImportedField, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImportParameters.
+func (in *RemoteObjectImportParameters) DeepCopy() *RemoteObjectImportParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImportParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImportSpec) DeepCopyInto(out *RemoteObjectImportSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImportSpec.
+func (in *RemoteObjectImportSpec) DeepCopy() *RemoteObjectImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectImportStatus) DeepCopyInto(out *RemoteObjectImportStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectImportStatus.
+func (in *RemoteObjectImportStatus) DeepCopy() *RemoteObjectImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectList) DeepCopyInto(out *RemoteObjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteObject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectList.
+func (in *RemoteObjectList) DeepCopy() *RemoteObjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteObjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectObservation) DeepCopyInto(out *RemoteObjectObservation) {
+	*out = *in
+	if in.Object != nil {
+		in, out := &in.Object, &out.Object
+		*out = new(ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectObservation.
+func (in *RemoteObjectObservation) DeepCopy() *RemoteObjectObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectParameters) DeepCopyInto(out *RemoteObjectParameters) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ReadinessCheck)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectParameters.
+func (in *RemoteObjectParameters) DeepCopy() *RemoteObjectParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectSpec) DeepCopyInto(out *RemoteObjectSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectSpec.
+func (in *RemoteObjectSpec) DeepCopy() *RemoteObjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteObjectStatus) DeepCopyInto(out *RemoteObjectStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteObjectStatus.
+func (in *RemoteObjectStatus) DeepCopy() *RemoteObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteResourceSpec) DeepCopyInto(out *RemoteResourceSpec) {
+	*out = *in
+	if in.ClusterReference != nil {
+		in, out := &in.ClusterReference, &out.ClusterReference
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.WriteConnectionSecretToReference != nil {
+		in, out := &in.WriteConnectionSecretToReference, &out.WriteConnectionSecretToReference
+		*out = new(corev1alpha1.SecretReference)
+		**out = **in
+	}
+	if in.ClaimReference != nil {
+		in, out := &in.ClaimReference, &out.ClaimReference
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+	if in.ClassReference != nil {
+		in, out := &in.ClassReference, &out.ClassReference
+		*out = new(v1.ObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteResourceSpec.
+func (in *RemoteResourceSpec) DeepCopy() *RemoteResourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteResourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImport) DeepCopyInto(out *RemoteSecretImport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImport.
+func (in *RemoteSecretImport) DeepCopy() *RemoteSecretImport {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretImport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImportList) DeepCopyInto(out *RemoteSecretImportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteSecretImport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImportList.
+func (in *RemoteSecretImportList) DeepCopy() *RemoteSecretImportList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretImportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImportObservation) DeepCopyInto(out *RemoteSecretImportObservation) {
+	*out = *in
+	if in.ImportedKeys != nil {
+		in, out := &in.ImportedKeys, &out.ImportedKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImportObservation.
+func (in *RemoteSecretImportObservation) DeepCopy() *RemoteSecretImportObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImportObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImportParameters) DeepCopyInto(out *RemoteSecretImportParameters) {
+	*out = *in
+	out.SecretReference = in.SecretReference
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]ImportedKey, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImportParameters.
+func (in *RemoteSecretImportParameters) DeepCopy() *RemoteSecretImportParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImportParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImportSpec) DeepCopyInto(out *RemoteSecretImportSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImportSpec.
+func (in *RemoteSecretImportSpec) DeepCopy() *RemoteSecretImportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretImportStatus) DeepCopyInto(out *RemoteSecretImportStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretImportStatus.
+func (in *RemoteSecretImportStatus) DeepCopy() *RemoteSecretImportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretImportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretSync) DeepCopyInto(out *RemoteSecretSync) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretSync.
+func (in *RemoteSecretSync) DeepCopy() *RemoteSecretSync {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretSync)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretSync) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretSyncList) DeepCopyInto(out *RemoteSecretSyncList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteSecretSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretSyncList.
+func (in *RemoteSecretSyncList) DeepCopy() *RemoteSecretSyncList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretSyncList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteSecretSyncList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretSyncSpec) DeepCopyInto(out *RemoteSecretSyncSpec) {
+	*out = *in
+	out.Source = in.Source
+	if in.ClusterReferences != nil {
+		in, out := &in.ClusterReferences, &out.ClusterReferences
+		*out = make([]v1.ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretSyncSpec.
+func (in *RemoteSecretSyncSpec) DeepCopy() *RemoteSecretSyncSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretSyncSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteSecretSyncStatus) DeepCopyInto(out *RemoteSecretSyncStatus) {
+	*out = *in
+	in.ConditionedStatus.DeepCopyInto(&out.ConditionedStatus)
+	if in.Clusters != nil {
+		in, out := &in.Clusters, &out.Clusters
+		*out = make([]ClusterSync, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteSecretSyncStatus.
+func (in *RemoteSecretSyncStatus) DeepCopy() *RemoteSecretSyncStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteSecretSyncStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenant) DeepCopyInto(out *RemoteTenant) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenant.
+func (in *RemoteTenant) DeepCopy() *RemoteTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteTenant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenantList) DeepCopyInto(out *RemoteTenantList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RemoteTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenantList.
+func (in *RemoteTenantList) DeepCopy() *RemoteTenantList {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenantList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RemoteTenantList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenantObservation) DeepCopyInto(out *RemoteTenantObservation) {
+	*out = *in
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = new(v1.ResourceQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]ObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenantObservation.
+func (in *RemoteTenantObservation) DeepCopy() *RemoteTenantObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenantObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenantParameters) DeepCopyInto(out *RemoteTenantParameters) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Quota != nil {
+		in, out := &in.Quota, &out.Quota
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]v1.LimitRangeItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Isolation != nil {
+		in, out := &in.Isolation, &out.Isolation
+		*out = new(TenantIsolation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RoleBindings != nil {
+		in, out := &in.RoleBindings, &out.RoleBindings
+		*out = make([]TenantRoleBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenantParameters.
+func (in *RemoteTenantParameters) DeepCopy() *RemoteTenantParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenantParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenantSpec) DeepCopyInto(out *RemoteTenantSpec) {
+	*out = *in
+	in.RemoteResourceSpec.DeepCopyInto(&out.RemoteResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenantSpec.
+func (in *RemoteTenantSpec) DeepCopy() *RemoteTenantSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenantSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoteTenantStatus) DeepCopyInto(out *RemoteTenantStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoteTenantStatus.
+func (in *RemoteTenantStatus) DeepCopy() *RemoteTenantStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RemoteTenantStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncSource) DeepCopyInto(out *SyncSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncSource.
+func (in *SyncSource) DeepCopy() *SyncSource {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantIsolation) DeepCopyInto(out *TenantIsolation) {
+	*out = *in
+	if in.AllowFromNamespaces != nil {
+		in, out := &in.AllowFromNamespaces, &out.AllowFromNamespaces
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantIsolation.
+func (in *TenantIsolation) DeepCopy() *TenantIsolation {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantIsolation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantRoleBinding) DeepCopyInto(out *TenantRoleBinding) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]rbacv1.Subject, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantRoleBinding.
+func (in *TenantRoleBinding) DeepCopy() *TenantRoleBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantRoleBinding)
+	in.DeepCopyInto(out)
+	return out
+}
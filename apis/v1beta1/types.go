@@ -22,9 +22,83 @@ import (
 	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
 )
 
-// A ProviderSpec defines the desired state of a Provider.
+// A ProviderSpec defines the desired state of a Provider. Credentials may be
+// supplied either as a kubeconfig file, via CredentialsSecretRef, or as a
+// Server URL and the discrete credentials required to connect to it.
 type ProviderSpec struct {
 	runtimev1alpha1.ProviderSpec `json:",inline"`
+
+	// Server is the URL of the API server of the cluster. It is used when
+	// CredentialsSecretRef is not specified.
+	// +optional
+	Server string `json:"server,omitempty"`
+
+	// CASecretRef references a secret key containing the PEM encoded CA
+	// certificate of the API server.
+	// +optional
+	CASecretRef *runtimev1alpha1.SecretKeySelector `json:"caSecretRef,omitempty"`
+
+	// TokenSecretRef references a secret key containing a bearer token used to
+	// authenticate to the API server.
+	// +optional
+	TokenSecretRef *runtimev1alpha1.SecretKeySelector `json:"tokenSecretRef,omitempty"`
+
+	// ClientCertSecretRef references a secret key containing a PEM encoded
+	// client certificate used to authenticate to the API server. It must be
+	// specified along with ClientKeySecretRef.
+	// +optional
+	ClientCertSecretRef *runtimev1alpha1.SecretKeySelector `json:"clientCertSecretRef,omitempty"`
+
+	// ClientKeySecretRef references a secret key containing the PEM encoded
+	// private key of the client certificate.
+	// +optional
+	ClientKeySecretRef *runtimev1alpha1.SecretKeySelector `json:"clientKeySecretRef,omitempty"`
+
+	// TLSSecretRef references a Secret of type kubernetes.io/tls, such as
+	// those issued by cert-manager, whose tls.crt and tls.key are used to
+	// authenticate to the API server. Its ca.crt, if any, is used as the CA
+	// certificate of the API server unless CASecretRef is specified. The
+	// Secret is read whenever the cluster is connected to, so renewed
+	// certificates are used automatically.
+	// +optional
+	TLSSecretRef *runtimev1alpha1.SecretReference `json:"tlsSecretRef,omitempty"`
+
+	// OIDC configures authentication to the API server using an ID token
+	// issued by an OpenID Connect provider. It is used along with Server.
+	// +optional
+	OIDC *OIDCConfig `json:"oidc,omitempty"`
+}
+
+// OIDCConfig configures how an ID token is obtained from an OpenID Connect
+// provider. The client credentials flow is used unless a refresh token is
+// specified, in which case the refresh token flow is used.
+type OIDCConfig struct {
+	// IssuerURL of the OpenID Connect provider. Its token endpoint is
+	// discovered from the issuer's /.well-known/openid-configuration.
+	IssuerURL string `json:"issuerURL"`
+
+	// ClientID of this Provider at the OpenID Connect provider.
+	ClientID string `json:"clientID"`
+
+	// ClientSecretSecretRef references a secret key containing the client
+	// secret of this Provider at the OpenID Connect provider.
+	ClientSecretSecretRef runtimev1alpha1.SecretKeySelector `json:"clientSecretSecretRef"`
+
+	// RefreshTokenSecretRef references a secret key containing a refresh
+	// token. The refresh token flow is used when it is specified.
+	// +optional
+	RefreshTokenSecretRef *runtimev1alpha1.SecretKeySelector `json:"refreshTokenSecretRef,omitempty"`
+
+	// Scopes to request in addition to the openid scope.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+// A ProviderStatus represents the observed state of a Provider.
+type ProviderStatus struct {
+	// Users is the number of ExistingClusters that reference this Provider.
+	// A Provider cannot be deleted while it has users.
+	Users int64 `json:"users,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -32,13 +106,17 @@ type ProviderSpec struct {
 // A Provider configures a GCP 'provider', i.e. a connection to a particular
 // GCP project using a particular GCP service account
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="USERS",type="integer",JSONPath=".status.users"
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentialsSecretRef.name",priority=1
+// +kubebuilder:printcolumn:name="SERVER",type="string",JSONPath=".spec.server",priority=1
+// +kubebuilder:subresource:status
 // +kubebuilder:resource:scope=Cluster
 type Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec ProviderSpec `json:"spec"`
+	Spec   ProviderSpec   `json:"spec"`
+	Status ProviderStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
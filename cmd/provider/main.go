@@ -28,6 +28,7 @@ import (
 
 	"github.com/turkenh/provider-existing-cluster/apis"
 	"github.com/turkenh/provider-existing-cluster/pkg/controller"
+	"github.com/turkenh/provider-existing-cluster/pkg/webhook"
 
 	crossplaneapis "github.com/crossplaneio/crossplane/apis"
 )
@@ -37,6 +38,9 @@ func main() {
 		app        = kingpin.New(filepath.Base(os.Args[0]), "ExistingCluster support for Crossplane.").DefaultEnvars()
 		debug      = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		syncPeriod = app.Flag("sync", "Controller manager sync period such as 300ms, 1.5h, or 2h45m").Short('s').Default("1h").Duration()
+		webhooks   = app.Flag("enable-webhooks", "Serve admission webhooks.").Bool()
+		certDir    = app.Flag("webhook-cert-dir", "Directory containing the webhook server's tls.crt and tls.key.").Default("/tmp/k8s-webhook-server/serving-certs").String()
+		port       = app.Flag("webhook-port", "Port at which to serve admission webhooks.").Default("9443").Int()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
@@ -54,11 +58,14 @@ func main() {
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
-	mgr, err := ctrl.NewManager(cfg, ctrl.Options{SyncPeriod: syncPeriod})
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{SyncPeriod: syncPeriod, CertDir: *certDir, Port: *port})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(crossplaneapis.AddToScheme(mgr.GetScheme()), "Cannot add core Crossplane APIs to scheme")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add GCP APIs to scheme")
 	kingpin.FatalIfError(controller.Setup(mgr, log), "Cannot setup GCP controllers")
+	if *webhooks {
+		kingpin.FatalIfError(webhook.Setup(mgr, log), "Cannot setup webhooks")
+	}
 	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
 }
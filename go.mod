@@ -3,15 +3,20 @@ module github.com/turkenh/provider-existing-cluster
 go 1.13
 
 require (
+	github.com/Azure/go-autorest v14.2.0+incompatible // indirect
 	github.com/crossplaneio/crossplane v0.8.0
 	github.com/crossplaneio/crossplane-runtime v0.5.0
 	github.com/crossplaneio/crossplane-tools v0.0.0-20200214190114-c7c4365eeb95
-	github.com/pkg/errors v0.8.1
+	github.com/google/go-cmp v0.4.0
+	github.com/pkg/errors v0.9.1
+	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
 	gopkg.in/alecthomas/kingpin.v2 v2.2.6
-	k8s.io/api v0.17.0
-	k8s.io/apimachinery v0.17.0
-	k8s.io/client-go v0.17.0
+	helm.sh/helm/v3 v3.1.0
+	k8s.io/api v0.17.2
+	k8s.io/apimachinery v0.17.2
+	k8s.io/client-go v0.17.2
 	sigs.k8s.io/controller-runtime v0.4.0
 	sigs.k8s.io/controller-tools v0.2.4
+	sigs.k8s.io/kustomize/api v0.3.2
 	sigs.k8s.io/yaml v1.1.0
 )
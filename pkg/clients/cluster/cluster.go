@@ -0,0 +1,175 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cluster contains utilities for connecting to existing clusters.
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+
+	"github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+)
+
+// Error strings.
+const (
+	errGetCredentialsSecret = "cannot get credentials Secret"
+	errGetSecret            = "cannot get Secret"
+	errNoCredentials        = "Provider must specify either credentialsSecretRef or server"
+	errClientCertWithoutKey = "Provider must specify clientKeySecretRef along with clientCertSecretRef"
+	errWriteKubeconfig      = "cannot write kubeconfig"
+	errNewRESTConfig        = "cannot create REST config from kubeconfig"
+	errNoCurrentCluster     = "kubeconfig has no current cluster"
+)
+
+// ClientFor returns a client for the cluster configured by the supplied
+// Provider.
+func ClientFor(ctx context.Context, kube client.Client, p *v1beta1.Provider) (client.Client, error) {
+	kc, err := GetKubeconfig(ctx, kube, p)
+	if err != nil {
+		return nil, err
+	}
+	return ClientForKubeconfig(kc)
+}
+
+// ClientForKubeconfig returns a client for the cluster configured by the
+// current context of the supplied kubeconfig.
+func ClientForKubeconfig(kc []byte) (client.Client, error) {
+	rc, err := clientcmd.RESTConfigFromKubeConfig(kc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRESTConfig)
+	}
+	return NewClient(rc)
+}
+
+// GetKubeconfig returns a kubeconfig that may be used to connect to the
+// cluster configured by the supplied Provider. The kubeconfig is read from the
+// Provider's credentials secret if one is referenced, and is otherwise built
+// from the Provider's server URL and discrete credentials.
+func GetKubeconfig(ctx context.Context, kube client.Client, p *v1beta1.Provider) ([]byte, error) {
+	if ref := p.Spec.CredentialsSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		n := types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+		if err := kube.Get(ctx, n, s); err != nil {
+			return nil, errors.Wrap(err, errGetCredentialsSecret)
+		}
+		return s.Data[runtimev1alpha1.ResourceCredentialsSecretKubeconfigKey], nil
+	}
+
+	if p.Spec.Server == "" {
+		return nil, errors.New(errNoCredentials)
+	}
+
+	c := &clientcmdapi.Cluster{Server: p.Spec.Server}
+	a := &clientcmdapi.AuthInfo{}
+
+	var err error
+	if c.CertificateAuthorityData, err = getSecretKey(ctx, kube, p.Spec.CASecretRef); err != nil {
+		return nil, err
+	}
+	if a.Token, err = getSecretString(ctx, kube, p.Spec.TokenSecretRef); err != nil {
+		return nil, err
+	}
+	if o := p.Spec.OIDC; o != nil {
+		if a.Token, err = getOIDCToken(ctx, kube, o); err != nil {
+			return nil, err
+		}
+	}
+	if p.Spec.ClientCertSecretRef != nil && p.Spec.ClientKeySecretRef == nil {
+		return nil, errors.New(errClientCertWithoutKey)
+	}
+	if a.ClientCertificateData, err = getSecretKey(ctx, kube, p.Spec.ClientCertSecretRef); err != nil {
+		return nil, err
+	}
+	if a.ClientKeyData, err = getSecretKey(ctx, kube, p.Spec.ClientKeySecretRef); err != nil {
+		return nil, err
+	}
+	if ref := p.Spec.TLSSecretRef; ref != nil {
+		if err := applyTLSSecret(ctx, kube, ref, c, a); err != nil {
+			return nil, err
+		}
+	}
+
+	return writeKubeconfig(p.GetName(), c, a)
+}
+
+// TokenKubeconfig returns a kubeconfig that connects to the cluster of the
+// current context of the supplied kubeconfig using the supplied bearer token.
+// The cluster, user, and context are all given the supplied name, and the
+// context defaults to the supplied namespace.
+func TokenKubeconfig(kc []byte, name, namespace, token string) ([]byte, error) {
+	in, err := clientcmd.Load(kc)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadKubeconfig)
+	}
+	cur, ok := in.Contexts[in.CurrentContext]
+	if !ok {
+		return nil, errors.New(errNoCurrentCluster)
+	}
+	c, ok := in.Clusters[cur.Cluster]
+	if !ok {
+		return nil, errors.New(errNoCurrentCluster)
+	}
+
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[name] = c
+	cfg.AuthInfos[name] = &clientcmdapi.AuthInfo{Token: token}
+	cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name, Namespace: namespace}
+	cfg.CurrentContext = name
+
+	out, err := clientcmd.Write(*cfg)
+	return out, errors.Wrap(err, errWriteKubeconfig)
+}
+
+// writeKubeconfig serializes a kubeconfig whose current context connects to
+// the supplied cluster using the supplied credentials. The cluster, user, and
+// context are all given the supplied name.
+func writeKubeconfig(name string, c *clientcmdapi.Cluster, a *clientcmdapi.AuthInfo) ([]byte, error) {
+	cfg := clientcmdapi.NewConfig()
+	cfg.Clusters[name] = c
+	cfg.AuthInfos[name] = a
+	cfg.Contexts[name] = &clientcmdapi.Context{Cluster: name, AuthInfo: name}
+	cfg.CurrentContext = name
+
+	out, err := clientcmd.Write(*cfg)
+	return out, errors.Wrap(err, errWriteKubeconfig)
+}
+
+// getSecretKey returns the value of the secret key selected by the supplied
+// selector, or nil if the selector is nil.
+func getSecretKey(ctx context.Context, kube client.Client, sel *runtimev1alpha1.SecretKeySelector) ([]byte, error) {
+	if sel == nil {
+		return nil, nil
+	}
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, s); err != nil {
+		return nil, errors.Wrap(err, errGetSecret)
+	}
+	return s.Data[sel.Key], nil
+}
+
+func getSecretString(ctx context.Context, kube client.Client, sel *runtimev1alpha1.SecretKeySelector) (string, error) {
+	b, err := getSecretKey(ctx, kube, sel)
+	return string(b), err
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// Error strings.
+const (
+	errNewRESTMapper = "cannot create REST mapper"
+	errNewClient     = "cannot create client"
+	errGetObject     = "cannot get remote object"
+	errDeleteObject  = "cannot delete remote object"
+	errConvertObject = "cannot convert object to unstructured"
+)
+
+// NewClient returns a client for the cluster described by the supplied REST
+// config. REST mappings are discovered lazily, so a client may be created for
+// a cluster that is not currently reachable.
+func NewClient(rc *rest.Config) (client.Client, error) {
+	m, err := apiutil.NewDynamicRESTMapper(rc, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRESTMapper)
+	}
+	c, err := client.New(rc, client.Options{Scheme: scheme.Scheme, Mapper: m})
+	return c, errors.Wrap(err, errNewClient)
+}
+
+// ToUnstructured converts the supplied typed object to an unstructured object
+// with its type metadata set. Unstructured objects are returned as is.
+func ToUnstructured(o runtime.Object) (*unstructured.Unstructured, error) {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		return u, nil
+	}
+	gvk, err := apiutil.GVKForObject(o, scheme.Scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertObject)
+	}
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, errors.Wrap(err, errConvertObject)
+	}
+
+	// The zero creation timestamp of a typed object is converted to null,
+	// which would never match that of an observed object.
+	unstructured.RemoveNestedField(m, "metadata", "creationTimestamp")
+
+	u := &unstructured.Unstructured{Object: m}
+	u.SetGroupVersionKind(gvk)
+	return u, nil
+}
+
+// ReferenceTo returns a reference to the supplied remote object.
+func ReferenceTo(o *unstructured.Unstructured) v1beta1.RemoteObjectReference {
+	return v1beta1.RemoteObjectReference{
+		APIVersion: o.GetAPIVersion(),
+		Kind:       o.GetKind(),
+		Namespace:  o.GetNamespace(),
+		Name:       o.GetName(),
+		UID:        o.GetUID(),
+	}
+}
+
+// ObjectFor returns an object with the identity of the supplied reference.
+func ObjectFor(r v1beta1.RemoteObjectReference) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(r.APIVersion)
+	u.SetKind(r.Kind)
+	u.SetNamespace(r.Namespace)
+	u.SetName(r.Name)
+	return u
+}
+
+// AnyExist returns true if any of the referenced objects exist. An object
+// with the referenced name but a different UID is not the referenced object.
+func AnyExist(ctx context.Context, c client.Client, refs []v1beta1.RemoteObjectReference) (bool, error) {
+	for _, r := range refs {
+		u := ObjectFor(r)
+		err := c.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Name}, u)
+		if resource.IgnoreNotFound(err) != nil {
+			return false, errors.Wrap(err, errGetObject)
+		}
+		if err == nil && (r.UID == "" || u.GetUID() == r.UID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// DeleteAll requests deletion of the referenced objects, in the reverse of the
+// order in which they are referenced. Objects that do not exist, or that were
+// recreated with a different UID, are ignored.
+func DeleteAll(ctx context.Context, c client.Client, refs []v1beta1.RemoteObjectReference) error {
+	for i := len(refs) - 1; i >= 0; i-- {
+		r := refs[i]
+		opts := []client.DeleteOption{}
+		if r.UID != "" {
+			opts = append(opts, client.Preconditions{UID: &r.UID})
+		}
+		// A UID precondition that is not met is reported as a conflict.
+		err := c.Delete(ctx, ObjectFor(r), opts...)
+		if kerrors.IsNotFound(err) || kerrors.IsConflict(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, errDeleteObject)
+		}
+	}
+	return nil
+}
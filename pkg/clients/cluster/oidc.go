@@ -0,0 +1,264 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+)
+
+// IDTokenExpiryDelta is how long before its expiry a cached ID token is
+// considered expired. Connection details are published using the cached
+// token, so this should exceed the interval at which they are republished.
+const IDTokenExpiryDelta = 5 * time.Minute
+
+// IDTokenIdleTimeout is how long a cached ID token may go unused before it is
+// evicted, for example because the Provider that used it was deleted.
+const IDTokenIdleTimeout = 1 * time.Hour
+
+const (
+	scopeOpenID     = "openid"
+	wellKnownPath   = "/.well-known/openid-configuration"
+	extraKeyIDToken = "id_token"
+)
+
+// Error strings.
+const (
+	errDiscoverIssuer  = "cannot discover OpenID Connect issuer configuration"
+	errIssuerStatus    = "unexpected OpenID Connect discovery response status"
+	errNoTokenEndpoint = "OpenID Connect issuer configuration does not specify a token_endpoint"
+	errGetToken        = "cannot get token from OpenID Connect provider"
+	errNoIDToken       = "token response does not contain an id_token"
+	errParseIDToken    = "cannot parse ID token"
+	errMalformedJWT    = "malformed JWT"
+)
+
+// IDTokens caches the ID tokens obtained for Providers that authenticate using
+// OpenID Connect.
+var IDTokens = NewIDTokenCache(http.DefaultClient)
+
+// An IDTokenCache caches ID tokens until shortly before they expire.
+type IDTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]*cachedIDToken
+	client *http.Client
+}
+
+// A cachedIDToken is locked while it is being fetched, so that tokens for
+// other credentials may be obtained concurrently.
+type cachedIDToken struct {
+	mu     sync.Mutex
+	token  string
+	expiry time.Time
+
+	// used is guarded by the mutex of the IDTokenCache.
+	used time.Time
+}
+
+// NewIDTokenCache returns an empty IDTokenCache that uses the supplied HTTP
+// client to talk to OpenID Connect providers.
+func NewIDTokenCache(c *http.Client) *IDTokenCache {
+	return &IDTokenCache{tokens: map[string]*cachedIDToken{}, client: c}
+}
+
+// OIDCCredentials are the credentials used to obtain an ID token.
+type OIDCCredentials struct {
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+	Scopes       []string
+}
+
+// key uniquely identifies a set of credentials, such that rotating any of them
+// invalidates the cached token.
+func (c OIDCCredentials) key() string {
+	h := sha256.New()
+	for _, s := range append([]string{c.IssuerURL, c.ClientID, c.ClientSecret, c.RefreshToken}, c.Scopes...) {
+		_, _ = h.Write([]byte(s))
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// IDToken returns a cached ID token for the supplied credentials, or obtains a
+// new one if no unexpired token is cached.
+func (c *IDTokenCache) IDToken(ctx context.Context, cr OIDCCredentials) (string, error) {
+	t := c.entry(cr.key())
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if time.Now().Add(IDTokenExpiryDelta).Before(t.expiry) {
+		return t.token, nil
+	}
+
+	token, expiry, err := c.fetch(ctx, cr)
+	if err != nil {
+		return "", err
+	}
+	t.token, t.expiry = token, expiry
+	return t.token, nil
+}
+
+// entry returns the cache entry for the supplied key, creating it if necessary.
+// Entries that have not been used for IDTokenIdleTimeout are evicted.
+func (c *IDTokenCache) entry(k string) *cachedIDToken {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for key, t := range c.tokens {
+		if now.Sub(t.used) > IDTokenIdleTimeout {
+			delete(c.tokens, key)
+		}
+	}
+
+	t, ok := c.tokens[k]
+	if !ok {
+		t = &cachedIDToken{}
+		c.tokens[k] = t
+	}
+	t.used = now
+	return t
+}
+
+// fetch obtains a new ID token for the supplied credentials, and returns it
+// along with its expiry.
+func (c *IDTokenCache) fetch(ctx context.Context, cr OIDCCredentials) (string, time.Time, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, c.client)
+
+	tokenURL, err := c.discoverTokenURL(ctx, cr.IssuerURL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	scopes := append([]string{scopeOpenID}, cr.Scopes...)
+
+	var t *oauth2.Token
+	if cr.RefreshToken != "" {
+		cfg := &oauth2.Config{ClientID: cr.ClientID, ClientSecret: cr.ClientSecret, Endpoint: oauth2.Endpoint{TokenURL: tokenURL}, Scopes: scopes}
+		t, err = cfg.TokenSource(ctx, &oauth2.Token{RefreshToken: cr.RefreshToken}).Token()
+	} else {
+		cfg := &clientcredentials.Config{ClientID: cr.ClientID, ClientSecret: cr.ClientSecret, TokenURL: tokenURL, Scopes: scopes}
+		t, err = cfg.Token(ctx)
+	}
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errGetToken)
+	}
+
+	id, ok := t.Extra(extraKeyIDToken).(string)
+	if !ok || id == "" {
+		return "", time.Time{}, errors.New(errNoIDToken)
+	}
+
+	exp, err := idTokenExpiry(id)
+	if err != nil {
+		return "", time.Time{}, errors.Wrap(err, errParseIDToken)
+	}
+	if exp.IsZero() {
+		// Fall back to the expiry of the access token if the ID token does not
+		// specify its own.
+		exp = t.Expiry
+	}
+
+	return id, exp, nil
+}
+
+func (c *IDTokenCache) discoverTokenURL(ctx context.Context, issuer string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, strings.TrimSuffix(issuer, "/")+wellKnownPath, nil)
+	if err != nil {
+		return "", errors.Wrap(err, errDiscoverIssuer)
+	}
+	rsp, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return "", errors.Wrap(err, errDiscoverIssuer)
+	}
+	defer rsp.Body.Close() //nolint:errcheck
+
+	if rsp.StatusCode != http.StatusOK {
+		return "", errors.Errorf("%s: %s", errIssuerStatus, rsp.Status)
+	}
+
+	cfg := struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}{}
+	if err := json.NewDecoder(rsp.Body).Decode(&cfg); err != nil {
+		return "", errors.Wrap(err, errDiscoverIssuer)
+	}
+	if cfg.TokenEndpoint == "" {
+		return "", errors.New(errNoTokenEndpoint)
+	}
+	return cfg.TokenEndpoint, nil
+}
+
+// idTokenExpiry returns the expiry of the supplied ID token, or the zero time
+// if it has none. The token's signature is not verified; it is verified by the
+// API server to which it is presented.
+func idTokenExpiry(token string) (time.Time, error) {
+	segments := strings.Split(token, ".")
+	if len(segments) != 3 {
+		return time.Time{}, errors.New(errMalformedJWT)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segments[1], "="))
+	if err != nil {
+		return time.Time{}, err
+	}
+	claims := struct {
+		Expiry int64 `json:"exp"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return time.Time{}, err
+	}
+	if claims.Expiry == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(claims.Expiry, 0), nil
+}
+
+// getOIDCToken returns an ID token for the supplied Provider OIDC config.
+func getOIDCToken(ctx context.Context, kube client.Client, o *v1beta1.OIDCConfig) (string, error) {
+	secret, err := getSecretString(ctx, kube, &o.ClientSecretSecretRef)
+	if err != nil {
+		return "", err
+	}
+	refresh, err := getSecretString(ctx, kube, o.RefreshTokenSecretRef)
+	if err != nil {
+		return "", err
+	}
+	return IDTokens.IDToken(ctx, OIDCCredentials{
+		IssuerURL:    o.IssuerURL,
+		ClientID:     o.ClientID,
+		ClientSecret: secret,
+		RefreshToken: refresh,
+		Scopes:       o.Scopes,
+	})
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "client"
+	testClientSecret = "secret"
+	testRefreshToken = "refresh"
+)
+
+// An idp is a fake OpenID Connect provider.
+type idp struct {
+	discoveryStatus int
+	noTokenEndpoint bool
+	noIDToken       bool
+
+	// expiry of issued ID tokens. Tokens have no exp claim if it is zero.
+	expiry time.Time
+
+	mu       sync.Mutex
+	requests int
+}
+
+// serve the fake provider. The returned server must be closed.
+func (p *idp) serve() *httptest.Server {
+	srv := httptest.NewServer(nil)
+	mux := http.NewServeMux()
+	mux.HandleFunc(wellKnownPath, func(w http.ResponseWriter, _ *http.Request) {
+		if p.discoveryStatus != 0 {
+			w.WriteHeader(p.discoveryStatus)
+			return
+		}
+		cfg := map[string]string{"issuer": srv.URL}
+		if !p.noTokenEndpoint {
+			cfg["token_endpoint"] = srv.URL + "/token"
+		}
+		_ = json.NewEncoder(w).Encode(cfg)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		p.requests++
+		p.mu.Unlock()
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		id, secret, ok := r.BasicAuth()
+		if !ok {
+			id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+		}
+
+		valid := id == testClientID && secret == testClientSecret
+		switch r.PostForm.Get("grant_type") {
+		case "client_credentials":
+		case "refresh_token":
+			valid = valid && r.PostForm.Get("refresh_token") == testRefreshToken
+		default:
+			valid = false
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if !valid {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+
+		rsp := map[string]interface{}{"access_token": "access", "token_type": "Bearer", "expires_in": 3600}
+		if !p.noIDToken {
+			rsp[extraKeyIDToken] = jwt(p.expiry)
+		}
+		_ = json.NewEncoder(w).Encode(rsp)
+	})
+	srv.Config.Handler = mux
+	return srv
+}
+
+func (p *idp) tokenRequests() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.requests
+}
+
+// jwt returns an unsigned JWT that expires at the supplied time.
+func jwt(exp time.Time) string {
+	claims := "{}"
+	if !exp.IsZero() {
+		claims = fmt.Sprintf(`{"exp":%d}`, exp.Unix())
+	}
+	enc := base64.RawURLEncoding.EncodeToString
+	return strings.Join([]string{enc([]byte(`{"alg":"none"}`)), enc([]byte(claims)), ""}, ".")
+}
+
+func TestIDToken(t *testing.T) {
+	expiry := time.Now().Add(1 * time.Hour)
+
+	type want struct {
+		token string
+		err   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		idp    *idp
+		creds  OIDCCredentials
+		want   want
+	}{
+		"ClientCredentials": {
+			reason: "An ID token should be obtained using the client credentials flow.",
+			idp:    &idp{expiry: expiry},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret},
+			want:   want{token: jwt(expiry)},
+		},
+		"RefreshToken": {
+			reason: "An ID token should be obtained using the refresh token flow.",
+			idp:    &idp{expiry: expiry},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret, RefreshToken: testRefreshToken},
+			want:   want{token: jwt(expiry)},
+		},
+		"InvalidClientCredentials": {
+			reason: "Errors returned by the token endpoint should be returned.",
+			idp:    &idp{expiry: expiry},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: "wrong"},
+			want:   want{err: errGetToken},
+		},
+		"InvalidRefreshToken": {
+			reason: "Errors returned by the token endpoint should be returned.",
+			idp:    &idp{expiry: expiry},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret, RefreshToken: "wrong"},
+			want:   want{err: errGetToken},
+		},
+		"DiscoveryFailed": {
+			reason: "An unsuccessful discovery response should be returned as an error.",
+			idp:    &idp{discoveryStatus: http.StatusNotFound},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret},
+			want:   want{err: errIssuerStatus},
+		},
+		"NoTokenEndpoint": {
+			reason: "A discovery response without a token_endpoint should be returned as an error.",
+			idp:    &idp{noTokenEndpoint: true},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret},
+			want:   want{err: errNoTokenEndpoint},
+		},
+		"NoIDToken": {
+			reason: "A token response without an id_token should be returned as an error.",
+			idp:    &idp{noIDToken: true},
+			creds:  OIDCCredentials{ClientID: testClientID, ClientSecret: testClientSecret},
+			want:   want{err: errNoIDToken},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			srv := tc.idp.serve()
+			defer srv.Close()
+			tc.creds.IssuerURL = srv.URL
+
+			token, err := NewIDTokenCache(http.DefaultClient).IDToken(context.Background(), tc.creds)
+			if tc.want.err == "" && err != nil {
+				t.Fatalf("\n%s\nIDToken(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.want.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.want.err)) {
+				t.Fatalf("\n%s\nIDToken(...): want error %q, got %v", tc.reason, tc.want.err, err)
+			}
+			if token != tc.want.token {
+				t.Errorf("\n%s\nIDToken(...): want token %q, got %q", tc.reason, tc.want.token, token)
+			}
+		})
+	}
+}
+
+func TestIDTokenCaching(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		expiry   time.Time
+		requests int
+	}{
+		"Unexpired": {
+			reason:   "An ID token should be cached until shortly before it expires.",
+			expiry:   time.Now().Add(1 * time.Hour),
+			requests: 1,
+		},
+		"NearExpiry": {
+			reason:   "An ID token that expires within IDTokenExpiryDelta should be refreshed.",
+			expiry:   time.Now().Add(IDTokenExpiryDelta / 2),
+			requests: 2,
+		},
+		"NoExpiryClaim": {
+			reason:   "An ID token without an exp claim should be cached until its access token expires.",
+			requests: 1,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := &idp{expiry: tc.expiry}
+			srv := p.serve()
+			defer srv.Close()
+			creds := OIDCCredentials{IssuerURL: srv.URL, ClientID: testClientID, ClientSecret: testClientSecret}
+
+			c := NewIDTokenCache(http.DefaultClient)
+			for i := 0; i < 2; i++ {
+				if _, err := c.IDToken(context.Background(), creds); err != nil {
+					t.Fatalf("\n%s\nIDToken(...): unexpected error: %v", tc.reason, err)
+				}
+			}
+			if got := p.tokenRequests(); got != tc.requests {
+				t.Errorf("\n%s\nIDToken(...): want %d token requests, got %d", tc.reason, tc.requests, got)
+			}
+		})
+	}
+}
+
+func TestIDTokenCacheEviction(t *testing.T) {
+	c := NewIDTokenCache(http.DefaultClient)
+	c.tokens["idle"] = &cachedIDToken{used: time.Now().Add(-2 * IDTokenIdleTimeout)}
+	c.tokens["recent"] = &cachedIDToken{used: time.Now()}
+
+	c.entry("new")
+
+	for k, want := range map[string]bool{"idle": false, "recent": true, "new": true} {
+		if _, got := c.tokens[k]; got != want {
+			t.Errorf("entry(...): cached %q: want %t, got %t", k, want, got)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// Error strings.
+const (
+	errListObjects = "cannot list remote objects"
+)
+
+// ListAll returns references to every object of the supplied kinds, in all
+// namespaces. Kinds that the cluster does not serve are ignored.
+func ListAll(ctx context.Context, c client.Client, kinds []schema.GroupVersionKind) ([]v1beta1.RemoteObjectReference, error) {
+	refs := []v1beta1.RemoteObjectReference{}
+	for _, k := range kinds {
+		l := &unstructured.UnstructuredList{}
+		l.SetGroupVersionKind(k.GroupVersion().WithKind(k.Kind + "List"))
+		err := c.List(ctx, l)
+		if kmeta.IsNoMatchError(err) {
+			continue
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "%s: %s", errListObjects, k)
+		}
+		for i := range l.Items {
+			u := &l.Items[i]
+			u.SetGroupVersionKind(k)
+			refs = append(refs, ReferenceTo(u))
+		}
+	}
+	return refs, nil
+}
+
+// DeleteAllExcept requests deletion of every object of the supplied kinds
+// that is not referenced by the supplied snapshot. Objects are identified by
+// UID, so an object that was recreated since the snapshot was taken is
+// deleted. It returns the number of such objects that still exist, including
+// those that are being deleted.
+func DeleteAllExcept(ctx context.Context, c client.Client, kinds []schema.GroupVersionKind, snapshot []v1beta1.RemoteObjectReference) (int, error) {
+	current, err := ListAll(ctx, c, kinds)
+	if err != nil {
+		return 0, err
+	}
+
+	keep := make(map[v1beta1.RemoteObjectReference]bool, len(snapshot))
+	for _, r := range snapshot {
+		keep[r] = true
+	}
+
+	remaining := 0
+	for _, r := range current {
+		if keep[r] {
+			continue
+		}
+		remaining++
+		if err := c.Delete(ctx, ObjectFor(r)); resource.IgnoreNotFound(err) != nil {
+			return remaining, errors.Wrap(err, errDeleteObject)
+		}
+	}
+	return remaining, nil
+}
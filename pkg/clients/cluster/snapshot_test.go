@@ -0,0 +1,147 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+var (
+	configMaps = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	widgets    = schema.GroupVersionKind{Group: "example.org", Version: "v1", Kind: "Widget"}
+)
+
+// configMap returns a reference to a ConfigMap with the supplied name and UID.
+func configMap(name string, uid types.UID) v1beta1.RemoteObjectReference {
+	return v1beta1.RemoteObjectReference{APIVersion: "v1", Kind: "ConfigMap", Namespace: "default", Name: name, UID: uid}
+}
+
+// listing returns a list function that lists the supplied ConfigMaps, and
+// reports that any other kind is not served.
+func listing(refs ...v1beta1.RemoteObjectReference) test.MockListFn {
+	return func(_ context.Context, obj runtime.Object, _ ...client.ListOption) error {
+		l := obj.(*unstructured.UnstructuredList)
+		if l.GetKind() != configMaps.Kind+"List" {
+			return &kmeta.NoKindMatchError{GroupKind: l.GroupVersionKind().GroupKind()}
+		}
+		for _, r := range refs {
+			u := ObjectFor(r)
+			u.SetUID(r.UID)
+			l.Items = append(l.Items, *u)
+		}
+		return nil
+	}
+}
+
+func TestDeleteAllExcept(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		remaining int
+		deleted   []string
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		list     test.MockListFn
+		delete   error
+		kinds    []schema.GroupVersionKind
+		snapshot []v1beta1.RemoteObjectReference
+		want     want
+	}{
+		"NothingChanged": {
+			reason:   "Objects that are in the snapshot should not be deleted.",
+			list:     listing(configMap("a", "1"), configMap("b", "2")),
+			kinds:    []schema.GroupVersionKind{configMaps},
+			snapshot: []v1beta1.RemoteObjectReference{configMap("a", "1"), configMap("b", "2")},
+			want:     want{remaining: 0},
+		},
+		"Created": {
+			reason:   "Objects that are not in the snapshot should be deleted.",
+			list:     listing(configMap("a", "1"), configMap("b", "2")),
+			kinds:    []schema.GroupVersionKind{configMaps},
+			snapshot: []v1beta1.RemoteObjectReference{configMap("a", "1")},
+			want:     want{remaining: 1, deleted: []string{"b"}},
+		},
+		"Recreated": {
+			reason:   "An object that was recreated with the name of an object in the snapshot should be deleted.",
+			list:     listing(configMap("a", "3")),
+			kinds:    []schema.GroupVersionKind{configMaps},
+			snapshot: []v1beta1.RemoteObjectReference{configMap("a", "1")},
+			want:     want{remaining: 1, deleted: []string{"a"}},
+		},
+		"KindNotServed": {
+			reason:   "Kinds that the cluster does not serve should be ignored.",
+			list:     listing(configMap("a", "1")),
+			kinds:    []schema.GroupVersionKind{widgets, configMaps},
+			snapshot: []v1beta1.RemoteObjectReference{},
+			want:     want{remaining: 1, deleted: []string{"a"}},
+		},
+		"ListError": {
+			reason: "Errors listing objects should be returned.",
+			list:   test.NewMockListFn(errBoom),
+			kinds:  []schema.GroupVersionKind{configMaps},
+			want:   want{err: errors.Wrapf(errBoom, "%s: %s", errListObjects, configMaps)},
+		},
+		"DeleteError": {
+			reason: "Errors deleting objects should be returned.",
+			list:   listing(configMap("a", "1")),
+			delete: errBoom,
+			kinds:  []schema.GroupVersionKind{configMaps},
+			want:   want{remaining: 1, deleted: []string{"a"}, err: errors.Wrap(errBoom, errDeleteObject)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var deleted []string
+			c := &test.MockClient{
+				MockList: tc.list,
+				MockDelete: func(_ context.Context, obj runtime.Object, _ ...client.DeleteOption) error {
+					deleted = append(deleted, obj.(*unstructured.Unstructured).GetName())
+					return tc.delete
+				},
+			}
+
+			remaining, err := DeleteAllExcept(context.Background(), c, tc.kinds, tc.snapshot)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nDeleteAllExcept(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.remaining, remaining); diff != "" {
+				t.Errorf("\n%s\nDeleteAllExcept(...): -want remaining, +got remaining:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\nDeleteAllExcept(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+)
+
+// keyCACert is the key of the CA certificate in a kubernetes.io/tls Secret, as
+// populated by cert-manager.
+const keyCACert = "ca.crt"
+
+// Error strings.
+const (
+	errGetTLSSecret   = "cannot get TLS Secret"
+	errLoadKubeconfig = "cannot load kubeconfig"
+	errNoCurrentUser  = "kubeconfig has no current user"
+	errDecodeCert     = "cannot decode PEM client certificate"
+	errParseCert      = "cannot parse client certificate"
+)
+
+// applyTLSSecret configures the supplied cluster and user to use the client
+// certificate, key, and CA certificate of the referenced TLS Secret. The CA
+// certificate is only used if the cluster does not already have one.
+func applyTLSSecret(ctx context.Context, kube client.Client, ref *runtimev1alpha1.SecretReference, c *clientcmdapi.Cluster, a *clientcmdapi.AuthInfo) error {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(err, errGetTLSSecret)
+	}
+
+	a.ClientCertificateData = s.Data[corev1.TLSCertKey]
+	a.ClientKeyData = s.Data[corev1.TLSPrivateKeyKey]
+	if len(c.CertificateAuthorityData) == 0 {
+		c.CertificateAuthorityData = s.Data[keyCACert]
+	}
+	return nil
+}
+
+// ClientCertificateExpiry returns the time at which the client certificate of
+// the current user of the supplied kubeconfig expires, or nil if the current
+// user does not authenticate using an embedded client certificate.
+func ClientCertificateExpiry(kubeconfig []byte) (*time.Time, error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadKubeconfig)
+	}
+	ctx, ok := cfg.Contexts[cfg.CurrentContext]
+	if !ok {
+		return nil, errors.New(errNoCurrentUser)
+	}
+	a, ok := cfg.AuthInfos[ctx.AuthInfo]
+	if !ok {
+		return nil, errors.New(errNoCurrentUser)
+	}
+	if len(a.ClientCertificateData) == 0 {
+		return nil, nil
+	}
+
+	// The first certificate is the leaf; any that follow are intermediates.
+	b, _ := pem.Decode(a.ClientCertificateData)
+	if b == nil {
+		return nil, errors.New(errDecodeCert)
+	}
+	crt, err := x509.ParseCertificate(b.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, errParseCert)
+	}
+	return &crt.NotAfter, nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"bytes"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/getter"
+	"helm.sh/helm/v3/pkg/repo"
+	"sigs.k8s.io/yaml"
+)
+
+// Error strings.
+const (
+	errLoadArchive    = "cannot load chart archive"
+	errLoadIndex      = "cannot load repository index"
+	errFindChart      = "cannot find chart in repository index"
+	errNoChartURLs    = "chart version in repository index has no URLs"
+	errResolveURL     = "cannot resolve chart URL"
+	errNewGetter      = "cannot create chart getter"
+	errDownloadChart  = "cannot download chart archive"
+	errUnmarshalValue = "cannot unmarshal values"
+)
+
+// LoadArchive loads a chart from the supplied chart archive, i.e. a gzipped
+// tarball as produced by helm package.
+func LoadArchive(archive []byte) (*chart.Chart, error) {
+	ch, err := loader.LoadArchive(bytes.NewReader(archive))
+	return ch, errors.Wrap(err, errLoadArchive)
+}
+
+// FindInIndex returns the supplied version of the named chart from the supplied
+// repository index, i.e. an index.yaml file. The latest version is returned if
+// no version is supplied. Version constraints such as ^1.2 are supported.
+func FindInIndex(index []byte, name, version string) (*repo.ChartVersion, error) {
+	i := &repo.IndexFile{}
+	if err := yaml.Unmarshal(index, i); err != nil {
+		return nil, errors.Wrap(err, errLoadIndex)
+	}
+	i.SortEntries()
+	cv, err := i.Get(name, version)
+	return cv, errors.Wrap(err, errFindChart)
+}
+
+// Download the supplied chart version from its repository. Relative chart URLs
+// are resolved against the supplied repository URL.
+func Download(cv *repo.ChartVersion, repoURL string) (*chart.Chart, error) {
+	if len(cv.URLs) == 0 {
+		return nil, errors.New(errNoChartURLs)
+	}
+	u, err := repo.ResolveReferenceURL(repoURL, cv.URLs[0])
+	if err != nil {
+		return nil, errors.Wrap(err, errResolveURL)
+	}
+
+	g, err := getter.NewHTTPGetter()
+	if err != nil {
+		return nil, errors.Wrap(err, errNewGetter)
+	}
+	b, err := g.Get(u)
+	if err != nil {
+		return nil, errors.Wrap(err, errDownloadChart)
+	}
+	return LoadArchive(b.Bytes())
+}
+
+// MergeValues unmarshals each of the supplied YAML documents and merges them,
+// in order. Maps are merged recursively; any other value replaces the value of
+// a previous document.
+func MergeValues(docs ...[]byte) (map[string]interface{}, error) {
+	out := map[string]interface{}{}
+	for _, d := range docs {
+		v := map[string]interface{}{}
+		if err := yaml.Unmarshal(d, &v); err != nil {
+			return nil, errors.Wrap(err, errUnmarshalValue)
+		}
+		out = merge(out, v)
+	}
+	return out, nil
+}
+
+func merge(dst, src map[string]interface{}) map[string]interface{} {
+	for k, v := range src {
+		sm, ok := v.(map[string]interface{})
+		if !ok {
+			dst[k] = v
+			continue
+		}
+		if dm, ok := dst[k].(map[string]interface{}); ok {
+			dst[k] = merge(dm, sm)
+			continue
+		}
+		dst[k] = v
+	}
+	return dst
+}
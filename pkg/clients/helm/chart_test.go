@@ -0,0 +1,103 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package helm
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMergeValues(t *testing.T) {
+	type want struct {
+		values map[string]interface{}
+		err    string
+	}
+
+	cases := map[string]struct {
+		reason string
+		docs   []string
+		want   want
+	}{
+		"NoDocuments": {
+			reason: "No documents should merge to empty values.",
+			want:   want{values: map[string]interface{}{}},
+		},
+		"EmptyDocuments": {
+			reason: "Empty documents should merge to empty values.",
+			docs:   []string{"", ""},
+			want:   want{values: map[string]interface{}{}},
+		},
+		"NestedMapsMerged": {
+			reason: "Maps should be merged recursively, with later documents taking precedence.",
+			docs: []string{
+				"image:\n  repository: nginx\n  tag: \"1.16\"\nreplicas: 1",
+				"image:\n  tag: \"1.17\"",
+			},
+			want: want{values: map[string]interface{}{
+				"image":    map[string]interface{}{"repository": "nginx", "tag": "1.17"},
+				"replicas": float64(1),
+			}},
+		},
+		"ListsReplaced": {
+			reason: "Lists should be replaced rather than merged.",
+			docs:   []string{"hosts: [a, b]", "hosts: [c]"},
+			want:   want{values: map[string]interface{}{"hosts": []interface{}{"c"}}},
+		},
+		"ScalarReplacesMap": {
+			reason: "A value that is not a map should replace a map.",
+			docs:   []string{"ingress:\n  enabled: true", "ingress: false"},
+			want:   want{values: map[string]interface{}{"ingress": false}},
+		},
+		"MapReplacesScalar": {
+			reason: "A map should replace a value that is not a map.",
+			docs:   []string{"ingress: false", "ingress:\n  enabled: true"},
+			want:   want{values: map[string]interface{}{"ingress": map[string]interface{}{"enabled": true}}},
+		},
+		"NullReplacesValue": {
+			reason: "A null value should replace the value of a previous document.",
+			docs:   []string{"resources:\n  limits: {}", "resources: null"},
+			want:   want{values: map[string]interface{}{"resources": nil}},
+		},
+		"InvalidDocument": {
+			reason: "A document that is not a YAML map should be returned as an error.",
+			docs:   []string{"replicas: 1", "- a"},
+			want:   want{err: errUnmarshalValue},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			docs := make([][]byte, 0, len(tc.docs))
+			for _, d := range tc.docs {
+				docs = append(docs, []byte(d))
+			}
+
+			values, err := MergeValues(docs...)
+			if tc.want.err == "" && err != nil {
+				t.Fatalf("\n%s\nMergeValues(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.want.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.want.err)) {
+				t.Fatalf("\n%s\nMergeValues(...): want error %q, got %v", tc.reason, tc.want.err, err)
+			}
+			if diff := cmp.Diff(tc.want.values, values); diff != "" {
+				t.Errorf("\n%s\nMergeValues(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package helm contains utilities for managing Helm releases in existing
+// clusters.
+package helm
+
+import (
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/action"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	"helm.sh/helm/v3/pkg/storage/driver"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// Error strings.
+const (
+	errLoadKubeconfig = "cannot load kubeconfig"
+	errInitHelm       = "cannot initialize Helm"
+	errGetRelease     = "cannot get Helm release"
+	errInstallRelease = "cannot install Helm release"
+	errUpgradeRelease = "cannot upgrade Helm release"
+	errUninstall      = "cannot uninstall Helm release"
+)
+
+// storageDriver is the Helm storage driver used to record releases. It is the
+// default of the Helm CLI, so that releases may also be inspected using it.
+const storageDriver = "secret"
+
+// A Client manages the Helm releases of one namespace of a cluster.
+type Client struct {
+	cfg       *action.Configuration
+	namespace string
+}
+
+// NewClient returns a client that manages the Helm releases of the supplied
+// namespace of the cluster described by the supplied kubeconfig.
+func NewClient(kubeconfig []byte, namespace string) (*Client, error) {
+	cfg, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		return nil, errors.Wrap(err, errLoadKubeconfig)
+	}
+
+	// Helm creates the objects of a release in the kubeconfig's namespace
+	// unless they specify their own.
+	g := &restClientGetter{config: clientcmd.NewDefaultClientConfig(*cfg, &clientcmd.ConfigOverrides{
+		Context: clientcmdapi.Context{Namespace: namespace},
+	})}
+
+	ac := &action.Configuration{}
+	if err := ac.Init(g, namespace, storageDriver, func(string, ...interface{}) {}); err != nil {
+		return nil, errors.Wrap(err, errInitHelm)
+	}
+	return &Client{cfg: ac, namespace: namespace}, nil
+}
+
+// Get returns the latest revision of the named release, or nil if the release
+// does not exist.
+func (c *Client) Get(name string) (*release.Release, error) {
+	rel, err := action.NewGet(c.cfg).Run(name)
+	if errors.Cause(err) == driver.ErrReleaseNotFound {
+		return nil, nil
+	}
+	return rel, errors.Wrap(err, errGetRelease)
+}
+
+// Install the supplied chart as the named release, using the supplied values.
+func (c *Client) Install(name string, ch *chart.Chart, values map[string]interface{}) (*release.Release, error) {
+	i := action.NewInstall(c.cfg)
+	i.ReleaseName = name
+	i.Namespace = c.namespace
+	rel, err := i.Run(ch, values)
+	return rel, errors.Wrap(err, errInstallRelease)
+}
+
+// Upgrade the named release to the supplied chart, using the supplied values.
+// Values of the previous revision of the release are not reused.
+func (c *Client) Upgrade(name string, ch *chart.Chart, values map[string]interface{}) (*release.Release, error) {
+	u := action.NewUpgrade(c.cfg)
+	u.Namespace = c.namespace
+	rel, err := u.Run(name, ch, values)
+	return rel, errors.Wrap(err, errUpgradeRelease)
+}
+
+// Uninstall the named release, purging its history. A release that does not
+// exist is ignored.
+func (c *Client) Uninstall(name string) error {
+	_, err := action.NewUninstall(c.cfg).Run(name)
+	if errors.Cause(err) == driver.ErrReleaseNotFound {
+		return nil
+	}
+	return errors.Wrap(err, errUninstall)
+}
+
+// InProgress returns true if an operation on the supplied release, for
+// example an upgrade, is in progress.
+func InProgress(rel *release.Release) bool {
+	switch rel.Info.Status {
+	case release.StatusPendingInstall, release.StatusPendingUpgrade, release.StatusPendingRollback, release.StatusUninstalling:
+		return true
+	default:
+		return false
+	}
+}
+
+// A restClientGetter supplies Helm with clients for the cluster described by
+// a kubeconfig that is held in memory, rather than read from disk.
+type restClientGetter struct {
+	config clientcmd.ClientConfig
+}
+
+func (g *restClientGetter) ToRESTConfig() (*rest.Config, error) {
+	return g.config.ClientConfig()
+}
+
+func (g *restClientGetter) ToDiscoveryClient() (discovery.CachedDiscoveryInterface, error) {
+	rc, err := g.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return nil, err
+	}
+	return memory.NewMemCacheClient(dc), nil
+}
+
+func (g *restClientGetter) ToRESTMapper() (kmeta.RESTMapper, error) {
+	dc, err := g.ToDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+	m := restmapper.NewDeferredDiscoveryRESTMapper(dc)
+	return restmapper.NewShortcutExpander(m, dc), nil
+}
+
+func (g *restClientGetter) ToRawKubeConfigLoader() clientcmd.ClientConfig {
+	return g.config
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package kustomize contains utilities for rendering kustomizations.
+package kustomize
+
+import (
+	"path"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/filesys"
+	"sigs.k8s.io/kustomize/api/krusty"
+)
+
+// Error strings.
+const (
+	errWriteFile    = "cannot write kustomization file"
+	errRender       = "cannot render kustomization"
+	errDecodeObject = "cannot decode rendered object"
+)
+
+// Files of a kustomization, by path.
+type Files map[string][]byte
+
+// Render the kustomization at the supplied path of the supplied files, which
+// are held in memory. The rendered objects are returned in the order in which
+// they should be applied; namespaces and custom resource definitions first.
+func Render(files Files, dir string) ([]*unstructured.Unstructured, error) {
+	fs := filesys.MakeFsInMemory()
+	for p, b := range files {
+		if err := fs.WriteFile(path.Join(filesys.Separator, p), b); err != nil {
+			return nil, errors.Wrap(err, errWriteFile)
+		}
+	}
+
+	m, err := krusty.MakeKustomizer(fs, krusty.MakeDefaultOptions()).Run(path.Join(filesys.Separator, dir))
+	if err != nil {
+		return nil, errors.Wrap(err, errRender)
+	}
+
+	// We round trip each object through JSON in order to decode it exactly as
+	// a client would decode it when reading it from a cluster.
+	rs := m.Resources()
+	objs := make([]*unstructured.Unstructured, len(rs))
+	for i, r := range rs {
+		b, err := r.MarshalJSON()
+		if err != nil {
+			return nil, errors.Wrap(err, errDecodeObject)
+		}
+		objs[i] = &unstructured.Unstructured{}
+		if err := objs[i].UnmarshalJSON(b); err != nil {
+			return nil, errors.Wrap(err, errDecodeObject)
+		}
+	}
+	return objs, nil
+}
@@ -20,8 +20,9 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/clientcmd"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -36,32 +37,53 @@ import (
 
 	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
 	v1beta12 "github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
 )
 
 // Error strings.
 const (
-	errGetProvider       = "cannot get Provider"
-	errGetProviderSecret = "cannot get Provider Secret"
-	errNotCluster        = "managed resource is not a ExistingCluster"
+	errGetProvider        = "cannot get Provider"
+	errGetKubeconfig      = "cannot get kubeconfig for Provider"
+	errNewRESTConfig      = "cannot create REST config from kubeconfig"
+	errNewDiscoveryClient = "cannot create discovery client"
+	errProbeCluster       = "cannot reach cluster API server"
+	errGetCertExpiry      = "cannot determine client certificate expiry"
+	errNewRemoteClient    = "cannot create client for cluster"
+	errObserveCreated     = "cannot observe objects created in cluster"
+	errDeleteCreated      = "cannot delete objects created in cluster"
+	errNotCluster         = "managed resource is not a ExistingCluster"
+	errSkipCleanup        = "skipping remote cleanup"
+	errDeletionProtected  = "deletion protection is enabled; set spec.forProvider.deletionProtection to false to allow deletion to proceed"
+)
+
+// Event reasons.
+const (
+	reasonCredentialsMissing event.Reason = "CredentialsMissing"
 )
 
 // SetupExistingCluster adds a controller that reconciles ExistingCluster
 // managed resources.
 func SetupExistingCluster(mgr ctrl.Manager, l logging.Logger) error {
 	name := managed.ControllerName(v1beta1.ExistingClusterGroupKind)
+	record := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1beta1.ExistingCluster{}).
-		Complete(managed.NewReconciler(mgr,
-			resource.ManagedKind(v1beta1.ExistingClusterGroupVersionKind),
-			managed.WithExternalConnecter(&clusterConnector{kube: mgr.GetClient()}),
-			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+		Complete(&pausableReconciler{
+			kube: mgr.GetClient(),
+			wrapped: managed.NewReconciler(mgr,
+				resource.ManagedKind(v1beta1.ExistingClusterGroupVersionKind),
+				managed.WithExternalConnecter(&clusterConnector{kube: mgr.GetClient(), reader: mgr.GetAPIReader(), record: record}),
+				managed.WithLogger(l.WithValues("controller", name)),
+				managed.WithRecorder(record)),
+		})
 }
 
 type clusterConnector struct {
-	kube client.Client
+	kube   client.Client
+	reader client.Reader
+	record event.Recorder
 }
 
 func (c *clusterConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
@@ -69,24 +91,88 @@ func (c *clusterConnector) Connect(ctx context.Context, mg resource.Managed) (ma
 	if !ok {
 		return nil, errors.New(errNotCluster)
 	}
+	resumed(i)
+
+	// Deletion protection is usually enforced by our validating webhook, but
+	// the webhook may not be installed. We hold our finalizer, and thus the
+	// connection secret, until deletion protection is explicitly disabled.
+	if meta.WasDeleted(i) && i.Spec.ForProvider.DeletionProtection {
+		return nil, errors.New(errDeletionProtected)
+	}
 
 	p := &v1beta12.Provider{}
 	if err := c.kube.Get(ctx, meta.NamespacedNameOf(i.Spec.ProviderReference), p); err != nil {
-		return nil, errors.Wrap(err, errGetProvider)
+		return c.orphan(i, errors.Wrap(err, errGetProvider))
+	}
+
+	kc, err := cluster.GetKubeconfig(ctx, c.kube, p)
+	if err != nil {
+		return c.orphan(i, errors.Wrap(err, errGetKubeconfig))
+	}
+
+	rc, err := clientcmd.RESTConfigFromKubeConfig(kc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRESTConfig)
+	}
+
+	dc, err := discovery.NewDiscoveryClientForConfig(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewDiscoveryClient)
+	}
+
+	remote, err := cluster.NewClient(rc)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewRemoteClient)
+	}
+
+	id, err := getControlPlaneID(ctx, c.reader)
+	if err != nil {
+		return nil, err
 	}
 
-	s := &corev1.Secret{}
-	n := types.NamespacedName{Namespace: p.Spec.CredentialsSecretRef.Namespace, Name: p.Spec.CredentialsSecretRef.Name}
-	if err := c.kube.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrap(err, errGetProviderSecret)
+	return &clusterExternal{
+		kube:           c.kube,
+		remote:         remote,
+		discovery:      dc,
+		controlPlaneID: id,
+		endpoint:       rc.Host,
+		caData:         rc.TLSClientConfig.CAData,
+		configData:     kc,
+	}, nil
+}
+
+// orphan allows the deletion of an ExistingCluster to proceed when its
+// credentials are irrecoverably missing. The managed reconciler must connect
+// before it can delete, so without this an ExistingCluster whose Provider or
+// credentials Secret was removed would keep its finalizer forever. We only do
+// so when there is nothing to clean up in the remote cluster; otherwise the
+// finalizer is kept until the credentials are restored.
+func (c *clusterConnector) orphan(cr *v1beta1.ExistingCluster, err error) (managed.ExternalClient, error) {
+	if !meta.WasDeleted(cr) || !kerrors.IsNotFound(errors.Cause(err)) || needsCleanup(cr) {
+		return nil, err
 	}
+	c.record.Event(cr, event.Warning(reasonCredentialsMissing, errors.Wrap(err, errSkipCleanup)))
 
-	return &clusterExternal{kube: c.kube, configData: s.Data[runtimev1alpha1.ResourceCredentialsSecretKubeconfigKey]}, nil
+	// A NopClient observes that the external resource does not exist, which
+	// causes the managed reconciler to unpublish our connection details and
+	// remove our finalizer without calling Delete.
+	return &managed.NopClient{}, nil
+}
+
+// needsCleanup returns true if the objects we created in the remote cluster
+// must be deleted before the supplied ExistingCluster's finalizer is removed.
+func needsCleanup(cr *v1beta1.ExistingCluster) bool {
+	return cr.GetReclaimPolicy() == runtimev1alpha1.ReclaimDelete && len(cr.Status.AtProvider.CreatedObjects) > 0
 }
 
 type clusterExternal struct {
-	kube       client.Client
-	configData []byte
+	kube           client.Client
+	remote         client.Client
+	discovery      discovery.DiscoveryInterface
+	controlPlaneID string
+	endpoint       string
+	caData         []byte
+	configData     []byte
 }
 
 func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -95,17 +181,103 @@ func (e *clusterExternal) Observe(ctx context.Context, mg resource.Managed) (man
 		return managed.ExternalObservation{}, errors.New(errNotCluster)
 	}
 
+	if meta.WasDeleted(cr) {
+		return e.observeDeletion(ctx, cr)
+	}
+
+	cr.Status.AtProvider.Endpoint = e.endpoint
+
+	exp, err := cluster.ClientCertificateExpiry(e.configData)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetCertExpiry)
+	}
+	cr.Status.AtProvider.ClientCertificateExpiry = nil
+	if exp != nil {
+		cr.Status.AtProvider.ClientCertificateExpiry = &metav1.Time{Time: *exp}
+	}
+
+	// We probe the cluster's API server to determine whether it is available.
+	// An unreachable cluster still exists, so we continue to publish its
+	// connection details. We can't observe our registration while the
+	// cluster is unreachable, so we assume it is unchanged.
+	v, err := e.discovery.ServerVersion()
+	if err != nil {
+		cr.Status.AtProvider.Status = v1beta1.ClusterStateUnreachable
+		cr.Status.AtProvider.StatusMessage = errors.Wrap(err, errProbeCluster).Error()
+		cr.Status.SetConditions(v1alpha1.Unavailable())
+		return managed.ExternalObservation{
+			ResourceExists:    true,
+			ResourceUpToDate:  true,
+			ConnectionDetails: connectionDetails(e.configData),
+		}, nil
+	}
+
+	// If the cluster we're connected to is not the one we first observed we
+	// block the ExistingCluster from use, and stop publishing connection
+	// details that would silently point its users at a different cluster.
+	verified, err := e.verifyIdentity(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !verified {
+		cr.Status.AtProvider.StatusMessage = errIdentityChanged
+		cr.Status.SetConditions(v1alpha1.Unavailable())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	cr.Status.AtProvider.KubernetesVersion = v.GitVersion
+	e.observeFacts(ctx, cr)
+
 	cr.Status.AtProvider.Status = v1beta1.ClusterStateRunning
+	cr.Status.AtProvider.StatusMessage = ""
 	cr.Status.SetConditions(v1alpha1.Available())
 	resource.SetBindable(cr)
 
+	// Our external resource is the registration of this ExistingCluster with
+	// the cluster it represents.
+	registered, upToDate, err := e.observeRegistration(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	idUpToDate, err := e.observeClusterID(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	return managed.ExternalObservation{
-		ResourceExists:    true,
-		ResourceUpToDate:  true,
+		ResourceExists:    registered,
+		ResourceUpToDate:  upToDate && idUpToDate,
 		ConnectionDetails: connectionDetails(e.configData),
 	}, nil
 }
 
+// observeDeletion observes an ExistingCluster that has been deleted. We
+// consider our external resource to exist until every object we created in the
+// remote cluster is gone, which prevents our finalizer from being removed
+// before cleanup is confirmed.
+func (e *clusterExternal) observeDeletion(ctx context.Context, cr *v1beta1.ExistingCluster) (managed.ExternalObservation, error) {
+	if !needsCleanup(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Our created objects are identified only by name, so we must not clean
+	// them up from a cluster other than the one we created them in.
+	verified, err := e.verifyIdentity(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if !verified {
+		return managed.ExternalObservation{}, errors.New(errIdentityChanged)
+	}
+
+	exists, err := cluster.AnyExist(ctx, e.remote, cr.Status.AtProvider.CreatedObjects)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errObserveCreated)
+	}
+	return managed.ExternalObservation{ResourceExists: exists, ConnectionDetails: connectionDetails(e.configData)}, nil
+}
+
 func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
 	cr, ok := mg.(*v1beta1.ExistingCluster)
 	if !ok {
@@ -113,15 +285,20 @@ func (e *clusterExternal) Create(ctx context.Context, mg resource.Managed) (mana
 	}
 	cr.SetConditions(v1alpha1.Creating())
 
-	return managed.ExternalCreation{}, nil
+	return managed.ExternalCreation{}, e.register(ctx, cr)
 }
 
 func (e *clusterExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
-	_, ok := mg.(*v1beta1.ExistingCluster)
+	cr, ok := mg.(*v1beta1.ExistingCluster)
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotCluster)
 	}
-	return managed.ExternalUpdate{}, nil
+	if identityChanged(cr) {
+		// There's nothing we can do to fix a changed identity; the Provider's
+		// credentials must be restored to point to the original cluster.
+		return managed.ExternalUpdate{}, errors.New(errIdentityChanged)
+	}
+	return managed.ExternalUpdate{}, e.reregister(ctx, cr)
 }
 
 func (e *clusterExternal) Delete(ctx context.Context, mg resource.Managed) error {
@@ -130,7 +307,10 @@ func (e *clusterExternal) Delete(ctx context.Context, mg resource.Managed) error
 		return errors.New(errNotCluster)
 	}
 	cr.SetConditions(runtimev1alpha1.Deleting())
-	return nil
+
+	// Only objects we created are deleted. Their deletion is confirmed by a
+	// subsequent Observe.
+	return errors.Wrap(cluster.DeleteAll(ctx, e.remote, cr.Status.AtProvider.CreatedObjects), errDeleteCreated)
 }
 
 // connectionSecret return secret object for cluster instance
@@ -147,6 +327,7 @@ func connectionDetails(rawConfig []byte) managed.ConnectionDetails {
 		// runtimev1alpha1.ResourceCredentialsSecretUserKey:       []byte(config.AuthInfos[user].Username),
 		runtimev1alpha1.ResourceCredentialsSecretUserKey:       []byte(user),
 		runtimev1alpha1.ResourceCredentialsSecretPasswordKey:   []byte(config.AuthInfos[user].Password),
+		runtimev1alpha1.ResourceCredentialsSecretTokenKey:      []byte(config.AuthInfos[user].Token),
 		runtimev1alpha1.ResourceCredentialsSecretCAKey:         config.Clusters[cluster].CertificateAuthorityData,
 		runtimev1alpha1.ResourceCredentialsSecretClientCertKey: config.AuthInfos[user].ClientCertificateData,
 		runtimev1alpha1.ResourceCredentialsSecretClientKeyKey:  config.AuthInfos[user].ClientKeyData,
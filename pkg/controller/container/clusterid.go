@@ -0,0 +1,120 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	kmeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// The KEP-2149 About API. We use unstructured objects rather than importing
+// its types, which are not yet published in a stable module.
+var clusterPropertyGVK = schema.GroupVersionKind{Group: "about.k8s.io", Version: "v1alpha1", Kind: "ClusterProperty"}
+
+const (
+	clusterPropertyID = "id.k8s.io"
+	fieldValue        = "value"
+)
+
+// Error strings.
+const (
+	errGetClusterID    = "cannot get id.k8s.io ClusterProperty"
+	errCreateClusterID = "cannot create id.k8s.io ClusterProperty"
+	errClusterIDValue  = "cannot read id.k8s.io ClusterProperty value"
+)
+
+// clusterIDPolicy returns the cluster ID policy of the supplied ExistingCluster.
+func clusterIDPolicy(cr *v1beta1.ExistingCluster) v1beta1.ClusterIDPolicy {
+	if p := cr.Spec.ForProvider.ClusterIDPolicy; p != "" {
+		return p
+	}
+	return v1beta1.ClusterIDPolicyIgnore
+}
+
+// observeClusterID reports the id.k8s.io ClusterProperty of the cluster in the
+// status of the supplied ExistingCluster, per its cluster ID policy. It returns
+// false if the ClusterProperty should be created but does not exist.
+func (e *clusterExternal) observeClusterID(ctx context.Context, cr *v1beta1.ExistingCluster) (bool, error) {
+	policy := clusterIDPolicy(cr)
+	if policy == v1beta1.ClusterIDPolicyIgnore {
+		cr.Status.AtProvider.ClusterID = ""
+		return true, nil
+	}
+
+	id, err := e.getClusterID(ctx)
+	if err != nil {
+		return false, err
+	}
+	cr.Status.AtProvider.ClusterID = id
+
+	return id != "" || policy != v1beta1.ClusterIDPolicyCreate, nil
+}
+
+// getClusterID returns the value of the cluster's id.k8s.io ClusterProperty,
+// or an empty string if it does not exist. A cluster that does not serve the
+// About API has no id.k8s.io ClusterProperty.
+func (e *clusterExternal) getClusterID(ctx context.Context) (string, error) {
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterPropertyGVK)
+	err := e.remote.Get(ctx, types.NamespacedName{Name: clusterPropertyID}, u)
+	if kerrors.IsNotFound(err) || kmeta.IsNoMatchError(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrap(err, errGetClusterID)
+	}
+
+	id, _, err := unstructured.NestedString(u.Object, "spec", fieldValue)
+	return id, errors.Wrap(err, errClusterIDValue)
+}
+
+// ensureClusterID creates the cluster's id.k8s.io ClusterProperty using the
+// name of the supplied ExistingCluster, if its cluster ID policy is Create and
+// the ClusterProperty does not exist. An existing ClusterProperty is never
+// changed, per the About API.
+func (e *clusterExternal) ensureClusterID(ctx context.Context, cr *v1beta1.ExistingCluster) error {
+	if clusterIDPolicy(cr) != v1beta1.ClusterIDPolicyCreate {
+		return nil
+	}
+
+	id, err := e.getClusterID(ctx)
+	if err != nil || id != "" {
+		return err
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(clusterPropertyGVK)
+	u.SetName(clusterPropertyID)
+	u.SetLabels(map[string]string{LabelKeyManagedBy: LabelValueManagedBy})
+	if err := unstructured.SetNestedField(u.Object, cr.GetName(), "spec", fieldValue); err != nil {
+		return errors.Wrap(err, errCreateClusterID)
+	}
+	if err := e.create(ctx, cr, u); err != nil {
+		return errors.Wrap(err, errCreateClusterID)
+	}
+
+	cr.Status.AtProvider.ClusterID = cr.GetName()
+	return nil
+}
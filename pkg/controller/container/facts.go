@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// Error strings.
+const (
+	errGetAPIGroups = "cannot discover API groups of cluster"
+	errListNodes    = "cannot list nodes of cluster"
+)
+
+// observeFacts reports facts about the cluster that may be used to choose
+// between ExistingClusters in the status of the supplied ExistingCluster.
+// Facts are best-effort; the provider's credentials may not allow them to be
+// observed. Facts that cannot be observed are omitted, and the FactsObserved
+// condition explains why.
+func (e *clusterExternal) observeFacts(ctx context.Context, cr *v1beta1.ExistingCluster) {
+	unavailable := []string{}
+
+	cr.Status.AtProvider.APIGroups = nil
+	if gl, err := e.discovery.ServerGroups(); err != nil {
+		unavailable = append(unavailable, errors.Wrap(err, errGetAPIGroups).Error())
+	} else {
+		groups := make([]string, 0, len(gl.Groups))
+		for _, g := range gl.Groups {
+			groups = append(groups, g.Name)
+		}
+		cr.Status.AtProvider.APIGroups = groups
+	}
+
+	cr.Status.AtProvider.ReadyNodes = 0
+	nl := &corev1.NodeList{}
+	if err := e.remote.List(ctx, nl); err != nil {
+		unavailable = append(unavailable, errors.Wrap(err, errListNodes).Error())
+	}
+	for _, n := range nl.Items {
+		if nodeReady(n) {
+			cr.Status.AtProvider.ReadyNodes++
+		}
+	}
+
+	if len(unavailable) > 0 {
+		cr.Status.SetConditions(v1beta1.FactsUnavailable(strings.Join(unavailable, "; ")))
+		return
+	}
+	cr.Status.SetConditions(v1beta1.FactsObserved())
+}
+
+func nodeReady(n corev1.Node) bool {
+	for _, c := range n.Status.Conditions {
+		if c.Type == corev1.NodeReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
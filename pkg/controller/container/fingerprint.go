@@ -0,0 +1,86 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// Error strings.
+const (
+	errGetKubeSystem   = "cannot get kube-system namespace of cluster"
+	errIdentityChanged = "cluster does not match the fingerprint recorded when it was first observed; the ExistingCluster's Provider may now point to a different cluster"
+)
+
+// fingerprint returns the fingerprint of the cluster we're connected to.
+func (e *clusterExternal) fingerprint(ctx context.Context) (*v1beta1.ClusterFingerprint, error) {
+	ns := &corev1.Namespace{}
+	if err := e.remote.Get(ctx, types.NamespacedName{Name: metav1.NamespaceSystem}, ns); err != nil {
+		return nil, errors.Wrap(err, errGetKubeSystem)
+	}
+
+	fp := &v1beta1.ClusterFingerprint{KubeSystemUID: string(ns.GetUID())}
+	if len(e.caData) > 0 {
+		h := sha256.Sum256(e.caData)
+		fp.CAHash = hex.EncodeToString(h[:])
+	}
+	return fp, nil
+}
+
+// verifyIdentity returns true if the cluster we're connected to matches the
+// fingerprint recorded for the supplied ExistingCluster, recording it if this
+// is the first time the cluster has been observed. Clusters are identified by
+// the UID of their kube-system namespace. The ExistingCluster's
+// ClusterIdentity condition is set accordingly.
+func (e *clusterExternal) verifyIdentity(ctx context.Context, cr *v1beta1.ExistingCluster) (bool, error) {
+	fp, err := e.fingerprint(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	want := cr.Status.AtProvider.Fingerprint
+	if want == nil {
+		cr.Status.AtProvider.Fingerprint = fp
+		cr.Status.SetConditions(v1beta1.IdentityVerified())
+		return true, nil
+	}
+
+	if want.KubeSystemUID != fp.KubeSystemUID {
+		cr.Status.SetConditions(v1beta1.IdentityChanged(errIdentityChanged))
+		return false, nil
+	}
+
+	// The same cluster with a different CA had its CA rotated.
+	want.CAHash = fp.CAHash
+	cr.Status.SetConditions(v1beta1.IdentityVerified())
+	return true, nil
+}
+
+// identityChanged returns true if the supplied ExistingCluster was last
+// observed to connect to a cluster that did not match its fingerprint.
+func identityChanged(cr *v1beta1.ExistingCluster) bool {
+	return cr.GetCondition(v1beta1.TypeClusterIdentity).Status == corev1.ConditionFalse
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+const (
+	pauseReconcileTimeout = 1 * time.Minute
+)
+
+// Error strings.
+const (
+	errGetCluster          = "cannot get ExistingCluster"
+	errUpdateClusterStatus = "cannot update ExistingCluster status"
+)
+
+// paused returns true if reconciliation of the supplied ExistingCluster is
+// paused.
+func paused(cr *v1beta1.ExistingCluster) bool {
+	return cr.GetAnnotations()[v1beta1.AnnotationKeyPaused] == "true"
+}
+
+// A pausableReconciler skips reconciliation of paused ExistingClusters. The
+// wrapped managed reconciler neither observes, creates, updates, nor deletes
+// a paused ExistingCluster, and its finalizer and last published connection
+// secret are left untouched, even if it is deleted.
+type pausableReconciler struct {
+	kube    client.Client
+	wrapped reconcile.Reconciler
+}
+
+// Reconcile a paused ExistingCluster by setting its ReconcilePaused
+// condition, or pass an unpaused ExistingCluster to the wrapped reconciler.
+// Removing the pause annotation triggers a watch event, so there's no need to
+// requeue a paused ExistingCluster.
+func (r *pausableReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), pauseReconcileTimeout)
+	defer cancel()
+
+	cr := &v1beta1.ExistingCluster{}
+	if err := r.kube.Get(ctx, req.NamespacedName, cr); err != nil {
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetCluster)
+	}
+
+	if !paused(cr) {
+		return r.wrapped.Reconcile(req)
+	}
+
+	if cr.GetCondition(v1beta1.TypeReconcilePaused).Status == corev1.ConditionTrue {
+		return reconcile.Result{}, nil
+	}
+	cr.SetConditions(v1beta1.ReconcilePaused())
+	return reconcile.Result{}, errors.Wrap(r.kube.Status().Update(ctx, cr), errUpdateClusterStatus)
+}
+
+// resumed records that reconciliation of the supplied ExistingCluster has
+// resumed, if it was previously paused.
+func resumed(cr *v1beta1.ExistingCluster) {
+	if cr.GetCondition(v1beta1.TypeReconcilePaused).Status == corev1.ConditionTrue {
+		cr.SetConditions(v1beta1.ReconcileResumed())
+	}
+}
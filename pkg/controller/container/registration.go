@@ -0,0 +1,192 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package container
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+)
+
+// Registration of an ExistingCluster with the cluster it represents.
+const (
+	// RegistrationNamespace is the namespace owned by this provider in each
+	// registered cluster.
+	RegistrationNamespace = "crossplane-existing-cluster"
+
+	// RegistrationConfigMapNamespace is the namespace of the ConfigMap that
+	// records which control plane manages a registered cluster. It is
+	// readable by all authenticated users of the cluster.
+	RegistrationConfigMapNamespace = metav1.NamespacePublic
+
+	// RegistrationConfigMapName is the name of the ConfigMap that records
+	// which control plane manages a registered cluster.
+	RegistrationConfigMapName = "crossplane-existing-cluster"
+
+	// LabelKeyManagedBy is applied to every object this provider creates in
+	// a registered cluster.
+	LabelKeyManagedBy   = "app.kubernetes.io/managed-by"
+	LabelValueManagedBy = "provider-existing-cluster"
+
+	keyExistingCluster    = "existingCluster"
+	keyExistingClusterUID = "existingClusterUID"
+	keyControlPlaneID     = "controlPlaneID"
+)
+
+// Error strings.
+const (
+	errGetControlPlaneID   = "cannot determine control plane identity"
+	errGetRegistration     = "cannot get cluster registration ConfigMap"
+	errRegisteredElsewhere = "cluster is registered to a different control plane"
+	errCreateNamespace     = "cannot create provider namespace in cluster"
+	errCreateRegistration  = "cannot create cluster registration ConfigMap"
+	errUpdateRegistration  = "cannot update cluster registration ConfigMap"
+)
+
+// getControlPlaneID returns the UID of the control plane's kube-system
+// namespace, which stably identifies the control plane.
+func getControlPlaneID(ctx context.Context, r client.Reader) (string, error) {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, types.NamespacedName{Name: metav1.NamespaceSystem}, ns); err != nil {
+		return "", errors.Wrap(err, errGetControlPlaneID)
+	}
+	return string(ns.GetUID()), nil
+}
+
+// registrationFor returns the registration ConfigMap for the supplied
+// ExistingCluster.
+func registrationFor(cr *v1beta1.ExistingCluster, controlPlaneID string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: RegistrationConfigMapNamespace,
+			Name:      RegistrationConfigMapName,
+			Labels:    map[string]string{LabelKeyManagedBy: LabelValueManagedBy},
+		},
+		Data: map[string]string{
+			keyExistingCluster:    cr.GetName(),
+			keyExistingClusterUID: string(cr.GetUID()),
+			keyControlPlaneID:     controlPlaneID,
+		},
+	}
+}
+
+// observeRegistration returns whether the supplied ExistingCluster is
+// registered with the cluster it represents, and whether that registration is
+// up to date. A cluster registered by another ExistingCluster of this control
+// plane, for example one that was deleted and recreated, is not up to date. A
+// cluster registered by a different control plane is an error.
+func (e *clusterExternal) observeRegistration(ctx context.Context, cr *v1beta1.ExistingCluster) (exists bool, upToDate bool, err error) {
+	cm := &corev1.ConfigMap{}
+	nn := types.NamespacedName{Namespace: RegistrationConfigMapNamespace, Name: RegistrationConfigMapName}
+	if err := e.remote.Get(ctx, nn, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, false, nil
+		}
+		return false, false, errors.Wrap(err, errGetRegistration)
+	}
+
+	if cm.Data[keyControlPlaneID] != e.controlPlaneID {
+		return false, false, errors.Errorf("%s: %s", errRegisteredElsewhere, cm.Data[keyControlPlaneID])
+	}
+
+	want := registrationFor(cr, e.controlPlaneID)
+	upToDate = cm.Data[keyExistingCluster] == want.Data[keyExistingCluster] &&
+		cm.Data[keyExistingClusterUID] == want.Data[keyExistingClusterUID]
+	return true, upToDate, nil
+}
+
+// register the supplied ExistingCluster with the cluster it represents by
+// creating our namespace and registration ConfigMap. Objects we create are
+// recorded so that they may be deleted along with the ExistingCluster.
+func (e *clusterExternal) register(ctx context.Context, cr *v1beta1.ExistingCluster) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:   RegistrationNamespace,
+		Labels: map[string]string{LabelKeyManagedBy: LabelValueManagedBy},
+	}}
+	if err := e.create(ctx, cr, ns); err != nil {
+		return errors.Wrap(err, errCreateNamespace)
+	}
+
+	if err := e.create(ctx, cr, registrationFor(cr, e.controlPlaneID)); err != nil {
+		return errors.Wrap(err, errCreateRegistration)
+	}
+
+	e.setRegistered(cr)
+	return e.ensureClusterID(ctx, cr)
+}
+
+// reregister updates the registration ConfigMap to record the supplied
+// ExistingCluster. The ConfigMap is only written if its data has changed.
+func (e *clusterExternal) reregister(ctx context.Context, cr *v1beta1.ExistingCluster) error {
+	cm := &corev1.ConfigMap{}
+	nn := types.NamespacedName{Namespace: RegistrationConfigMapNamespace, Name: RegistrationConfigMapName}
+	if err := e.remote.Get(ctx, nn, cm); err != nil {
+		return errors.Wrap(err, errGetRegistration)
+	}
+	if want := registrationFor(cr, e.controlPlaneID).Data; !reflect.DeepEqual(cm.Data, want) {
+		cm.Data = want
+		if err := e.remote.Update(ctx, cm); err != nil {
+			return errors.Wrap(err, errUpdateRegistration)
+		}
+	}
+
+	e.setRegistered(cr)
+	return e.ensureClusterID(ctx, cr)
+}
+
+// setRegistered records that the supplied ExistingCluster was registered, if
+// it has not already been recorded.
+func (e *clusterExternal) setRegistered(cr *v1beta1.ExistingCluster) {
+	if cr.Status.AtProvider.Registration != nil {
+		return
+	}
+	cr.Status.AtProvider.Registration = &v1beta1.ClusterRegistration{
+		ControlPlaneID: e.controlPlaneID,
+		Namespace:      RegistrationNamespace,
+		Time:           metav1.Now(),
+	}
+}
+
+// create the supplied object in the remote cluster and record that we created
+// it. An object that already exists was not created by us, and is not
+// recorded.
+func (e *clusterExternal) create(ctx context.Context, cr *v1beta1.ExistingCluster, o runtime.Object) error {
+	err := e.remote.Create(ctx, o)
+	if kerrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	u, err := cluster.ToUnstructured(o)
+	if err != nil {
+		return err
+	}
+	cr.Status.AtProvider.CreatedObjects = append(cr.Status.AtProvider.CreatedObjects, cluster.ReferenceTo(u))
+	return nil
+}
@@ -21,6 +21,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/turkenh/provider-existing-cluster/pkg/controller/container"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/pool"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/provider"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/remote"
 )
 
 // Setup creates all GCP controllers with the supplied logger and adds them to
@@ -28,6 +31,20 @@ import (
 func Setup(mgr ctrl.Manager, l logging.Logger) error {
 	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
 		container.SetupExistingCluster,
+		container.SetupExistingClusterClaimScheduling,
+		container.SetupExistingClusterClaimDefaulting,
+		container.SetupExistingClusterClaimBinding,
+		pool.SetupExistingClusterPool,
+		provider.SetupProvider,
+		remote.SetupRemoteNamespace,
+		remote.SetupRemoteObject,
+		remote.SetupRemoteObjectImport,
+		remote.SetupRemoteHelmRelease,
+		remote.SetupRemoteKustomization,
+		remote.SetupRemoteSecretSync,
+		remote.SetupRemoteSecretImport,
+		remote.SetupRemoteCRDBundle,
+		remote.SetupRemoteTenant,
 	} {
 		if err := setup(mgr, l); err != nil {
 			return err
@@ -0,0 +1,393 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+const (
+	reconcileTimeout = 1 * time.Minute
+	shortWait        = 30 * time.Second
+)
+
+// Error strings.
+const (
+	errGetPool          = "cannot get ExistingClusterPool"
+	errListPools        = "cannot list ExistingClusterPools"
+	errSelector         = "cannot parse ExistingClusterPool selector"
+	errListClusters     = "cannot list ExistingClusters"
+	errListClaims       = "cannot list KubernetesCluster claims"
+	errDeleteClaim      = "cannot delete KubernetesCluster claim with expired lease"
+	errUpdateClaim      = "cannot lease ExistingCluster to KubernetesCluster claim"
+	errUpdateCluster    = "cannot return ExistingCluster to pool"
+	errUpdatePoolStatus = "cannot update ExistingClusterPool status"
+)
+
+// Event reasons.
+const (
+	reasonLeased       event.Reason = "LeasedCluster"
+	reasonLeaseExpired event.Reason = "LeaseExpired"
+	reasonReturned     event.Reason = "ReturnedCluster"
+	reasonScrubbing    event.Reason = "ScrubbingCluster"
+	reasonCannotScrub  event.Reason = "CannotScrubCluster"
+	reasonCannotLease  event.Reason = "CannotLeaseCluster"
+)
+
+// SetupExistingClusterPool adds a controller that leases the ExistingClusters
+// selected by each ExistingClusterPool to the KubernetesCluster claims that
+// reference it.
+func SetupExistingClusterPool(mgr ctrl.Manager, l logging.Logger) error {
+	name := "pool/" + strings.ToLower(v1beta1.ExistingClusterPoolKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    l.WithValues("controller", name),
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1beta1.ExistingClusterPool{}).
+		Watches(&source.Kind{Type: &computev1alpha1.KubernetesCluster{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(PoolOf)}).
+		Watches(&source.Kind{Type: &v1beta1.ExistingCluster{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: &PoolsSelecting{client: mgr.GetClient()}}).
+		Complete(r)
+}
+
+// PoolOf maps a KubernetesCluster claim to a request for the
+// ExistingClusterPool it references, if any.
+func PoolOf(o handler.MapObject) []reconcile.Request {
+	cm, ok := o.Object.(*computev1alpha1.KubernetesCluster)
+	if !ok || !referencesPool(cm) {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: cm.GetClassReference().Name}}}
+}
+
+// PoolsSelecting maps an ExistingCluster to requests for the
+// ExistingClusterPools that select it.
+type PoolsSelecting struct {
+	client client.Reader
+}
+
+// Map an ExistingCluster to requests for the ExistingClusterPools that select
+// it.
+func (m *PoolsSelecting) Map(o handler.MapObject) []reconcile.Request {
+	l := &v1beta1.ExistingClusterPoolList{}
+	if err := m.client.List(context.TODO(), l); err != nil {
+		return nil
+	}
+
+	rs := []reconcile.Request{}
+	for _, p := range l.Items {
+		s, err := metav1.LabelSelectorAsSelector(&p.Spec.Selector)
+		if err != nil || !s.Matches(labels.Set(o.Meta.GetLabels())) {
+			continue
+		}
+		rs = append(rs, reconcile.Request{NamespacedName: types.NamespacedName{Name: p.GetName()}})
+	}
+	return rs
+}
+
+// referencesPool returns true if the supplied claim references an
+// ExistingClusterPool as its class.
+func referencesPool(cm *computev1alpha1.KubernetesCluster) bool {
+	ref := cm.GetClassReference()
+	return ref != nil && ref.APIVersion == v1beta1.SchemeGroupVersion.String() && ref.Kind == v1beta1.ExistingClusterPoolKind
+}
+
+// referencesCluster returns true if the supplied claim references an
+// ExistingCluster as its resource.
+func referencesCluster(cm *computev1alpha1.KubernetesCluster) bool {
+	ref := cm.GetResourceReference()
+	return ref != nil && ref.APIVersion == v1beta1.SchemeGroupVersion.String() && ref.Kind == v1beta1.ExistingClusterKind
+}
+
+// leasable returns true if the supplied ExistingCluster may be leased to a
+// claim.
+func leasable(ec *v1beta1.ExistingCluster) bool {
+	return !meta.WasDeleted(ec) &&
+		resource.IsBindable(ec) &&
+		ec.GetClaimReference() == nil &&
+		resource.IsConditionTrue(ec.GetCondition(runtimev1alpha1.TypeReady)) &&
+		ec.GetAnnotations()[v1beta1.AnnotationKeyPaused] != "true" &&
+		!scrubbing(ec)
+}
+
+// A Reconciler leases the ExistingClusters in an ExistingClusterPool to the
+// KubernetesCluster claims that reference it. A lease is made by setting the
+// claim's resource reference to an available ExistingCluster, which is then
+// bound to the claim as if it had been statically provisioned. When the claim
+// is deleted its ExistingCluster is released, and returned to the pool.
+type Reconciler struct {
+	client client.Client
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile an ExistingClusterPool's leases.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) { // nolint:gocyclo
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	p := &v1beta1.ExistingClusterPool{}
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		// There's no need to requeue if we no longer exist. Otherwise we'll be
+		// requeued implicitly because we return an error.
+		log.Debug("Cannot get ExistingClusterPool", "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetPool)
+	}
+
+	s, err := metav1.LabelSelectorAsSelector(&p.Spec.Selector)
+	if err != nil {
+		p.Status.SetConditions(runtimev1alpha1.ReconcileError(errors.Wrap(err, errSelector)))
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdatePoolStatus)
+	}
+
+	clusters := &v1beta1.ExistingClusterList{}
+	if err := r.client.List(ctx, clusters, client.MatchingLabelsSelector{Selector: s}); err != nil {
+		log.Debug("Cannot list ExistingClusters", "error", err)
+		return reconcile.Result{}, errors.Wrap(err, errListClusters)
+	}
+
+	claims := &computev1alpha1.KubernetesClusterList{}
+	if err := r.client.List(ctx, claims); err != nil {
+		log.Debug("Cannot list KubernetesCluster claims", "error", err)
+		return reconcile.Result{}, errors.Wrap(err, errListClaims)
+	}
+
+	// An ExistingCluster referenced by any claim is not available, whether or
+	// not that claim references this pool.
+	referenced := map[string]bool{}
+	for i := range claims.Items {
+		if cm := &claims.Items[i]; referencesCluster(cm) {
+			referenced[cm.GetResourceReference().Name] = true
+		}
+	}
+
+	previous := map[types.UID]v1beta1.ClusterLease{}
+	for _, l := range p.Status.Leases {
+		previous[l.ClaimReference.UID] = l
+	}
+
+	now := metav1.Now()
+	leases := []v1beta1.ClusterLease{}
+	pending := []*computev1alpha1.KubernetesCluster{}
+	for i := range claims.Items {
+		cm := &claims.Items[i]
+		if !referencesPool(cm) || cm.GetClassReference().Name != p.GetName() || meta.WasDeleted(cm) {
+			continue
+		}
+
+		if cm.GetResourceReference() == nil {
+			pending = append(pending, cm)
+			continue
+		}
+
+		// A lease starts when the claim was updated to reference its
+		// ExistingCluster. Claims we did not lease are treated as leased
+		// when we first observed them.
+		l, ok := previous[cm.GetUID()]
+		if t, recorded := leaseTime(cm); recorded {
+			l = newLease(p, cm, cm.GetResourceReference().Name, t)
+		} else if !ok || l.ClusterName != cm.GetResourceReference().Name {
+			l = newLease(p, cm, cm.GetResourceReference().Name, now)
+		}
+
+		if l.ExpirationTime != nil && !now.Before(l.ExpirationTime) {
+			// Deleting the claim releases its ExistingCluster, which we'll
+			// return to the pool once it has been unbound.
+			if err := r.client.Delete(ctx, cm); resource.IgnoreNotFound(err) != nil {
+				log.Debug("Cannot delete claim with expired lease", "error", err)
+				return reconcile.Result{}, errors.Wrap(err, errDeleteClaim)
+			}
+			r.record.Event(p, event.Normal(reasonLeaseExpired, "Deleted claim "+cm.GetNamespace()+"/"+cm.GetName()+" with expired lease"))
+			continue
+		}
+
+		leases = append(leases, l)
+	}
+
+	var requeue time.Duration
+	available := []*v1beta1.ExistingCluster{}
+	for i := range clusters.Items {
+		ec := &clusters.Items[i]
+		if referenced[ec.GetName()] {
+			continue
+		}
+
+		// A released ExistingCluster cannot be bound again; we return it to
+		// the pool by making it bindable, once it has been scrubbed.
+		if ec.GetBindingPhase() == runtimev1alpha1.BindingPhaseReleased && ec.GetClaimReference() == nil && !meta.WasDeleted(ec) {
+			returned, err := r.returnToPool(ctx, p, ec)
+			if err != nil {
+				log.Debug("Cannot return ExistingCluster to pool", "error", err)
+				return reconcile.Result{}, err
+			}
+			if !returned {
+				// Scrubbing is not complete until deleted objects are gone,
+				// which we don't watch.
+				r.record.Event(p, event.Normal(reasonScrubbing, "Scrubbing ExistingCluster "+ec.GetName()+": "+ec.GetCondition(v1beta1.TypeScrubbed).Message))
+				requeue = shortWait
+				continue
+			}
+			r.record.Event(p, event.Normal(reasonReturned, "Returned ExistingCluster "+ec.GetName()+" to pool"))
+		}
+
+		if leasable(ec) {
+			available = append(available, ec)
+		}
+	}
+
+	// Claims are leased the first available ExistingCluster that satisfies
+	// their requirements, in the order they were created.
+	sort.SliceStable(pending, func(i, j int) bool {
+		return pending[i].CreationTimestamp.Before(&pending[j].CreationTimestamp)
+	})
+	leased := 0
+	taken := map[string]bool{}
+	for _, cm := range pending {
+		req, err := requirementsOf(cm)
+		if err != nil {
+			log.Debug("Cannot determine claim requirements", "error", err)
+			r.record.Event(p, event.Warning(reasonCannotLease, errors.Wrap(err, cm.GetNamespace()+"/"+cm.GetName())))
+			continue
+		}
+
+		for _, ec := range available {
+			if taken[ec.GetName()] || !satisfies(ec, p.Spec.Requirements) || !satisfies(ec, req) {
+				continue
+			}
+			taken[ec.GetName()] = true
+
+			// We record the state of the cluster before it is leased, so that
+			// we know what to scrub when it is returned.
+			if p.Spec.Scrub != nil {
+				if err := r.snapshot(ctx, p, ec); err != nil {
+					log.Debug("Cannot snapshot ExistingCluster", "error", err)
+					r.record.Event(p, event.Warning(reasonCannotLease, errors.Wrap(err, ec.GetName())))
+					continue
+				}
+			}
+
+			// The lease time is recorded on the claim in the same update
+			// that leases it, so that its lease cannot be extended by a lost
+			// status update.
+			start := metav1.NewTime(now.Truncate(time.Second))
+			cm.SetResourceReference(meta.ReferenceTo(ec, v1beta1.ExistingClusterGroupVersionKind))
+			meta.AddAnnotations(cm, map[string]string{v1beta1.AnnotationKeyLeaseTime: start.UTC().Format(time.RFC3339)})
+			if err := r.client.Update(ctx, cm); err != nil {
+				log.Debug("Cannot lease ExistingCluster to claim", "error", err)
+				return reconcile.Result{}, errors.Wrap(err, errUpdateClaim)
+			}
+			leases = append(leases, newLease(p, cm, ec.GetName(), start))
+			r.record.Event(p, event.Normal(reasonLeased, "Leased ExistingCluster "+ec.GetName()+" to claim "+cm.GetNamespace()+"/"+cm.GetName()))
+			leased++
+			break
+		}
+	}
+
+	p.Status.Capacity = int64(len(clusters.Items))
+	p.Status.Available = int64(len(available) - leased)
+	p.Status.Leased = int64(len(leases))
+	p.Status.Pending = int64(len(pending) - leased)
+	p.Status.Leases = leases
+	p.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+
+	// We'll be requeued when a claim or ExistingCluster changes, but must
+	// check back in order to expire leases and finish scrubbing.
+	if exp := untilNextExpiry(leases, now); exp > 0 && (requeue == 0 || exp < requeue) {
+		requeue = exp
+	}
+	return reconcile.Result{RequeueAfter: requeue}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdatePoolStatus)
+}
+
+// newLease returns a lease of the named ExistingCluster to the supplied claim,
+// starting at the supplied time.
+func newLease(p *v1beta1.ExistingClusterPool, cm *computev1alpha1.KubernetesCluster, cluster string, now metav1.Time) v1beta1.ClusterLease {
+	l := v1beta1.ClusterLease{
+		ClusterName: cluster,
+		ClaimReference: corev1.ObjectReference{
+			APIVersion: computev1alpha1.SchemeGroupVersion.String(),
+			Kind:       computev1alpha1.KubernetesClusterKind,
+			Namespace:  cm.GetNamespace(),
+			Name:       cm.GetName(),
+			UID:        cm.GetUID(),
+		},
+		Time: now,
+	}
+	if p.Spec.LeaseTTL != nil {
+		exp := metav1.NewTime(now.Add(p.Spec.LeaseTTL.Duration))
+		l.ExpirationTime = &exp
+	}
+	return l
+}
+
+// leaseTime returns the time at which the supplied claim was leased its
+// ExistingCluster, and whether that time was recorded.
+func leaseTime(cm *computev1alpha1.KubernetesCluster) (metav1.Time, bool) {
+	t, err := time.Parse(time.RFC3339, cm.GetAnnotations()[v1beta1.AnnotationKeyLeaseTime])
+	if err != nil {
+		return metav1.Time{}, false
+	}
+	return metav1.NewTime(t), true
+}
+
+// untilNextExpiry returns the duration until the first of the supplied leases
+// expires, or zero if none expire.
+func untilNextExpiry(leases []v1beta1.ClusterLease, now metav1.Time) time.Duration {
+	var next time.Duration
+	for _, l := range leases {
+		if l.ExpirationTime == nil {
+			continue
+		}
+		d := l.ExpirationTime.Sub(now.Time)
+		if d <= 0 {
+			d = time.Second
+		}
+		if next == 0 || d < next {
+			next = d
+		}
+	}
+	return next
+}
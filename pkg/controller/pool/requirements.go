@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/version"
+
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// Error strings.
+const (
+	errParseMinReadyNodes = "cannot parse minimum ready nodes annotation"
+)
+
+// requirementsOf returns the requirements expressed by the annotations of the
+// supplied claim, if any.
+func requirementsOf(cm *computev1alpha1.KubernetesCluster) (*v1beta1.ClusterRequirements, error) {
+	a := cm.GetAnnotations()
+	r := &v1beta1.ClusterRequirements{MinKubernetesVersion: a[v1beta1.AnnotationKeyMinKubernetesVersion]}
+
+	if s, ok := a[v1beta1.AnnotationKeyMinReadyNodes]; ok {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, errParseMinReadyNodes)
+		}
+		r.MinReadyNodes = &n
+	}
+
+	for _, g := range strings.Split(a[v1beta1.AnnotationKeyAPIGroups], ",") {
+		if g = strings.TrimSpace(g); g != "" {
+			r.APIGroups = append(r.APIGroups, g)
+		}
+	}
+
+	return r, nil
+}
+
+// satisfies returns true if the supplied ExistingCluster was observed to
+// satisfy the supplied requirements. An ExistingCluster whose version cannot
+// be parsed, or a requirement that cannot be parsed, is never satisfied.
+func satisfies(ec *v1beta1.ExistingCluster, r *v1beta1.ClusterRequirements) bool {
+	if r == nil {
+		return true
+	}
+
+	if r.MinKubernetesVersion != "" {
+		min, err := version.ParseGeneric(r.MinKubernetesVersion)
+		if err != nil {
+			return false
+		}
+		v, err := version.ParseGeneric(ec.Status.AtProvider.KubernetesVersion)
+		if err != nil || !v.AtLeast(min) {
+			return false
+		}
+	}
+
+	if r.MinReadyNodes != nil && ec.Status.AtProvider.ReadyNodes < *r.MinReadyNodes {
+		return false
+	}
+
+	served := make(map[string]bool, len(ec.Status.AtProvider.APIGroups))
+	for _, g := range ec.Status.AtProvider.APIGroups {
+		served[g] = true
+	}
+	for _, g := range r.APIGroups {
+		if !served[g] {
+			return false
+		}
+	}
+
+	return true
+}
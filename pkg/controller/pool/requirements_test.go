@@ -0,0 +1,151 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+	computev1alpha1 "github.com/crossplaneio/crossplane/apis/compute/v1alpha1"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+func TestRequirementsOf(t *testing.T) {
+	three := int64(3)
+	_, errParse := strconv.ParseInt("many", 10, 64)
+
+	type want struct {
+		r   *v1beta1.ClusterRequirements
+		err error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		want        want
+	}{
+		"NoAnnotations": {
+			reason: "A claim without annotations should have no requirements.",
+			want:   want{r: &v1beta1.ClusterRequirements{}},
+		},
+		"AllAnnotations": {
+			reason: "Each annotation should be parsed into its requirement.",
+			annotations: map[string]string{
+				v1beta1.AnnotationKeyMinKubernetesVersion: "v1.16",
+				v1beta1.AnnotationKeyMinReadyNodes:        "3",
+				v1beta1.AnnotationKeyAPIGroups:            "apps, ,batch,",
+			},
+			want: want{r: &v1beta1.ClusterRequirements{
+				MinKubernetesVersion: "v1.16",
+				MinReadyNodes:        &three,
+				APIGroups:            []string{"apps", "batch"},
+			}},
+		},
+		"InvalidMinReadyNodes": {
+			reason:      "A minimum ready nodes annotation that is not an integer should be returned as an error.",
+			annotations: map[string]string{v1beta1.AnnotationKeyMinReadyNodes: "many"},
+			want:        want{err: errors.Wrap(errParse, errParseMinReadyNodes)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cm := &computev1alpha1.KubernetesCluster{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			r, err := requirementsOf(cm)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nrequirementsOf(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.r, r); diff != "" {
+				t.Errorf("\n%s\nrequirementsOf(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSatisfies(t *testing.T) {
+	three := int64(3)
+
+	cases := map[string]struct {
+		reason string
+		obs    v1beta1.ExistingClusterObservation
+		r      *v1beta1.ClusterRequirements
+		want   bool
+	}{
+		"NoRequirements": {
+			reason: "Any ExistingCluster should satisfy nil requirements.",
+			want:   true,
+		},
+		"AllSatisfied": {
+			reason: "An ExistingCluster that was observed to meet every requirement should satisfy them.",
+			obs: v1beta1.ExistingClusterObservation{
+				KubernetesVersion: "v1.17.2-gke.1",
+				ReadyNodes:        3,
+				APIGroups:         []string{"apps", "batch"},
+			},
+			r: &v1beta1.ClusterRequirements{
+				MinKubernetesVersion: "1.16",
+				MinReadyNodes:        &three,
+				APIGroups:            []string{"apps"},
+			},
+			want: true,
+		},
+		"VersionTooOld": {
+			reason: "An ExistingCluster older than the minimum version should not satisfy the requirements.",
+			obs:    v1beta1.ExistingClusterObservation{KubernetesVersion: "v1.15.9"},
+			r:      &v1beta1.ClusterRequirements{MinKubernetesVersion: "v1.16"},
+			want:   false,
+		},
+		"VersionUnknown": {
+			reason: "An ExistingCluster whose version was not observed should not satisfy a minimum version.",
+			r:      &v1beta1.ClusterRequirements{MinKubernetesVersion: "v1.16"},
+			want:   false,
+		},
+		"InvalidMinVersion": {
+			reason: "A minimum version that cannot be parsed should never be satisfied.",
+			obs:    v1beta1.ExistingClusterObservation{KubernetesVersion: "v1.17.2"},
+			r:      &v1beta1.ClusterRequirements{MinKubernetesVersion: "latest"},
+			want:   false,
+		},
+		"TooFewReadyNodes": {
+			reason: "An ExistingCluster with fewer ready nodes than required should not satisfy the requirements.",
+			obs:    v1beta1.ExistingClusterObservation{ReadyNodes: 2},
+			r:      &v1beta1.ClusterRequirements{MinReadyNodes: &three},
+			want:   false,
+		},
+		"MissingAPIGroup": {
+			reason: "An ExistingCluster that does not serve a required API group should not satisfy the requirements.",
+			obs:    v1beta1.ExistingClusterObservation{APIGroups: []string{"apps"}},
+			r:      &v1beta1.ClusterRequirements{APIGroups: []string{"apps", "batch"}},
+			want:   false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ec := &v1beta1.ExistingCluster{Status: v1beta1.ExistingClusterStatus{AtProvider: tc.obs}}
+			if got := satisfies(ec, tc.r); got != tc.want {
+				t.Errorf("\n%s\nsatisfies(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
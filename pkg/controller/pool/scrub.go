@@ -0,0 +1,207 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pool
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	apisv1beta1 "github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/container"
+)
+
+const (
+	// SnapshotConfigMapPrefix prefixes the name of the ConfigMap that records
+	// the objects that existed in a cluster when it was leased. The ConfigMap
+	// is created in the control plane, where tenants of the cluster cannot
+	// tamper with it, and is named for the leased ExistingCluster.
+	SnapshotConfigMapPrefix = "lease-snapshot-"
+
+	// DefaultSnapshotNamespace is the namespace in which snapshot ConfigMaps
+	// are created if the scrub policy does not specify one.
+	DefaultSnapshotNamespace = "crossplane-system"
+
+	keySnapshot = "snapshot"
+)
+
+// Error strings.
+const (
+	errNoProvider       = "ExistingCluster has no Provider"
+	errGetProvider      = "cannot get Provider"
+	errConnect          = "cannot connect to cluster"
+	errEncodeSnapshot   = "cannot encode lease snapshot"
+	errDecodeSnapshot   = "cannot decode lease snapshot"
+	errGetSnapshot      = "cannot get lease snapshot ConfigMap"
+	errNoSnapshot       = "lease snapshot ConfigMap does not exist; cannot determine which objects were created while leased"
+	errApplySnapshot    = "cannot apply lease snapshot ConfigMap"
+	errDeleteSnapshot   = "cannot delete lease snapshot ConfigMap"
+	errScrubInProgress  = "waiting for objects created while leased to be deleted"
+	errUpdateScrubState = "cannot update ExistingCluster scrub status"
+)
+
+var (
+	namespaceKind = corev1.SchemeGroupVersion.WithKind("Namespace")
+
+	defaultScrubKinds = []schema.GroupVersionKind{
+		{Group: "apiextensions.k8s.io", Version: "v1beta1", Kind: "CustomResourceDefinition"},
+		rbacv1.SchemeGroupVersion.WithKind("ClusterRole"),
+		rbacv1.SchemeGroupVersion.WithKind("ClusterRoleBinding"),
+	}
+)
+
+// scrubKinds returns the kinds of object that the supplied pool scrubs.
+func scrubKinds(p *v1beta1.ExistingClusterPool) []schema.GroupVersionKind {
+	kinds := []schema.GroupVersionKind{namespaceKind}
+	if len(p.Spec.Scrub.ClusterScopedKinds) == 0 {
+		return append(kinds, defaultScrubKinds...)
+	}
+	for _, k := range p.Spec.Scrub.ClusterScopedKinds {
+		kinds = append(kinds, schema.FromAPIVersionAndKind(k.APIVersion, k.Kind))
+	}
+	return kinds
+}
+
+// connect to the cluster represented by the supplied ExistingCluster.
+func (r *Reconciler) connect(ctx context.Context, ec *v1beta1.ExistingCluster) (client.Client, error) {
+	if ec.Spec.ProviderReference == nil {
+		return nil, errors.New(errNoProvider)
+	}
+	pr := &apisv1beta1.Provider{}
+	if err := r.client.Get(ctx, meta.NamespacedNameOf(ec.Spec.ProviderReference), pr); err != nil {
+		return nil, errors.Wrap(err, errGetProvider)
+	}
+	c, err := cluster.ClientFor(ctx, r.client, pr)
+	return c, errors.Wrap(err, errConnect)
+}
+
+// snapshotName returns the name of the ConfigMap that records the objects
+// that existed in the cluster represented by the supplied ExistingCluster when
+// it was leased from the supplied pool.
+func snapshotName(p *v1beta1.ExistingClusterPool, ec *v1beta1.ExistingCluster) types.NamespacedName {
+	ns := p.Spec.Scrub.SnapshotNamespace
+	if ns == "" {
+		ns = DefaultSnapshotNamespace
+	}
+	return types.NamespacedName{Namespace: ns, Name: SnapshotConfigMapPrefix + ec.GetName()}
+}
+
+// snapshot records the objects that the supplied pool scrubs that exist in
+// the cluster represented by the supplied ExistingCluster. The snapshot is
+// controlled by the ExistingCluster, and is thus garbage collected with it.
+func (r *Reconciler) snapshot(ctx context.Context, p *v1beta1.ExistingClusterPool, ec *v1beta1.ExistingCluster) error {
+	rc, err := r.connect(ctx, ec)
+	if err != nil {
+		return err
+	}
+
+	refs, err := cluster.ListAll(ctx, rc, scrubKinds(p))
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(refs)
+	if err != nil {
+		return errors.Wrap(err, errEncodeSnapshot)
+	}
+
+	nn := snapshotName(p, ec)
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: nn.Namespace, Name: nn.Name}}
+	_, err = controllerutil.CreateOrUpdate(ctx, r.client, cm, func() error {
+		meta.AddLabels(cm, map[string]string{container.LabelKeyManagedBy: container.LabelValueManagedBy})
+		meta.AddOwnerReference(cm, meta.AsController(meta.ReferenceTo(ec, v1beta1.ExistingClusterGroupVersionKind)))
+		cm.Data = map[string]string{keySnapshot: string(b)}
+		return nil
+	})
+	return errors.Wrap(err, errApplySnapshot)
+}
+
+// scrub the cluster represented by the supplied ExistingCluster of the objects
+// created since it was leased. It returns true once they have been deleted. A
+// cluster without a snapshot cannot be scrubbed, and is never considered to be
+// scrubbed; returning it to the pool could leak the objects of its previous
+// lessee.
+func (r *Reconciler) scrub(ctx context.Context, p *v1beta1.ExistingClusterPool, ec *v1beta1.ExistingCluster) (bool, error) {
+	cm := &corev1.ConfigMap{}
+	if err := r.client.Get(ctx, snapshotName(p, ec), cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, errors.New(errNoSnapshot)
+		}
+		return false, errors.Wrap(err, errGetSnapshot)
+	}
+
+	refs := []v1beta1.RemoteObjectReference{}
+	if err := json.Unmarshal([]byte(cm.Data[keySnapshot]), &refs); err != nil {
+		return false, errors.Wrap(err, errDecodeSnapshot)
+	}
+
+	rc, err := r.connect(ctx, ec)
+	if err != nil {
+		return false, err
+	}
+
+	remaining, err := cluster.DeleteAllExcept(ctx, rc, scrubKinds(p), refs)
+	if err != nil || remaining > 0 {
+		return false, err
+	}
+
+	return true, errors.Wrap(resource.IgnoreNotFound(r.client.Delete(ctx, cm)), errDeleteSnapshot)
+}
+
+// returnToPool returns the supplied released ExistingCluster to the supplied
+// pool by making it bindable, scrubbing it first if the pool requires. It
+// returns false if the ExistingCluster has yet to be scrubbed, in which case
+// its Scrubbed condition explains why.
+func (r *Reconciler) returnToPool(ctx context.Context, p *v1beta1.ExistingClusterPool, ec *v1beta1.ExistingCluster) (bool, error) {
+	if p.Spec.Scrub != nil {
+		scrubbed, err := r.scrub(ctx, p, ec)
+		if err != nil || !scrubbed {
+			msg := errScrubInProgress
+			if err != nil {
+				msg = err.Error()
+				r.record.Event(ec, event.Warning(reasonCannotScrub, err))
+			}
+			ec.SetConditions(v1beta1.Scrubbing(msg))
+			return false, errors.Wrap(r.client.Status().Update(ctx, ec), errUpdateScrubState)
+		}
+		ec.SetConditions(v1beta1.Scrubbed())
+	}
+
+	ec.SetBindingPhase(runtimev1alpha1.BindingPhaseUnbound)
+	return true, errors.Wrap(r.client.Status().Update(ctx, ec), errUpdateCluster)
+}
+
+// scrubbing returns true if the supplied ExistingCluster has yet to be
+// scrubbed since it was last leased.
+func scrubbing(ec *v1beta1.ExistingCluster) bool {
+	return ec.GetCondition(v1beta1.TypeScrubbed).Status == corev1.ConditionFalse
+}
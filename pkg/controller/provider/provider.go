@@ -0,0 +1,163 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	containerv1beta1 "github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+)
+
+const (
+	// Finalizer is added to a Provider while it is in use by at least one
+	// ExistingCluster.
+	Finalizer = "in-use.dev.crossplane.io"
+
+	reconcileTimeout = 1 * time.Minute
+	shortWait        = 30 * time.Second
+)
+
+// Error strings.
+const (
+	errGetProvider    = "cannot get Provider"
+	errListClusters   = "cannot list ExistingClusters"
+	errUpdateProvider = "cannot update Provider"
+	errUpdateStatus   = "cannot update Provider status"
+	errProviderInUse  = "cannot delete Provider: in use by %d ExistingCluster(s)"
+)
+
+// Event reasons.
+const (
+	reasonProviderInUse event.Reason = "ProviderInUse"
+)
+
+// SetupProvider adds a controller that tracks which ExistingClusters use each
+// Provider, and prevents a Provider from being deleted while it is in use.
+func SetupProvider(mgr ctrl.Manager, l logging.Logger) error {
+	name := "provider/" + strings.ToLower(v1beta1.ProviderKind)
+
+	r := &Reconciler{
+		client: mgr.GetClient(),
+		log:    l.WithValues("controller", name),
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1beta1.Provider{}).
+		Watches(&source.Kind{Type: &containerv1beta1.ExistingCluster{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: handler.ToRequestsFunc(ProviderOf)}).
+		Complete(r)
+}
+
+// ProviderOf maps an ExistingCluster to a request for the Provider it uses.
+func ProviderOf(o handler.MapObject) []reconcile.Request {
+	ec, ok := o.Object.(*containerv1beta1.ExistingCluster)
+	if !ok || ec.Spec.ProviderReference == nil {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: ec.Spec.ProviderReference.Name}}}
+}
+
+// A Reconciler counts the users of a Provider and holds a finalizer on it
+// while that count is non-zero.
+type Reconciler struct {
+	client client.Client
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile a Provider's usage.
+func (r *Reconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(context.Background(), reconcileTimeout)
+	defer cancel()
+
+	p := &v1beta1.Provider{}
+	if err := r.client.Get(ctx, req.NamespacedName, p); err != nil {
+		// There's no need to requeue if we no longer exist. Otherwise we'll be
+		// requeued implicitly because we return an error.
+		log.Debug("Cannot get Provider", "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetProvider)
+	}
+
+	users, err := r.countUsers(ctx, p)
+	if err != nil {
+		log.Debug("Cannot count Provider users", "error", err)
+		return reconcile.Result{}, err
+	}
+
+	if meta.WasDeleted(p) {
+		if users > 0 {
+			// Our users may be waiting on this Provider in order to process
+			// their own deletion, so we keep our finalizer until they're gone.
+			// We'll be requeued when an ExistingCluster that uses us changes,
+			// but we check back after a short wait to be safe.
+			err := errors.Errorf(errProviderInUse, users)
+			log.Debug("Cannot delete Provider", "error", err, "requeue-after", time.Now().Add(shortWait))
+			r.record.Event(p, event.Warning(reasonProviderInUse, err))
+			p.Status.Users = users
+			return reconcile.Result{RequeueAfter: shortWait}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+		}
+
+		meta.RemoveFinalizer(p, Finalizer)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(r.client.Update(ctx, p)), errUpdateProvider)
+	}
+
+	if !meta.FinalizerExists(p, Finalizer) {
+		meta.AddFinalizer(p, Finalizer)
+		if err := r.client.Update(ctx, p); err != nil {
+			log.Debug("Cannot add Provider finalizer", "error", err)
+			return reconcile.Result{}, errors.Wrap(err, errUpdateProvider)
+		}
+	}
+
+	p.Status.Users = users
+	return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, p), errUpdateStatus)
+}
+
+func (r *Reconciler) countUsers(ctx context.Context, p *v1beta1.Provider) (int64, error) {
+	l := &containerv1beta1.ExistingClusterList{}
+	if err := r.client.List(ctx, l); err != nil {
+		return 0, errors.Wrap(err, errListClusters)
+	}
+
+	users := int64(0)
+	for _, ec := range l.Items {
+		if ref := ec.Spec.ProviderReference; ref != nil && ref.Name == p.GetName() {
+			users++
+		}
+	}
+	return users, nil
+}
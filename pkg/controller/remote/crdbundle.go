@@ -0,0 +1,308 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// Error strings.
+const (
+	errNotCRDBundle       = "managed resource is not a RemoteCRDBundle"
+	errNotCRD             = "manifest is not a CustomResourceDefinition"
+	errNoCRDName          = "CustomResourceDefinition manifest must specify a name"
+	errGetCRD             = "cannot get CustomResourceDefinition"
+	errReadStoredVersions = "cannot read stored versions of CustomResourceDefinition"
+	errDropStoredVersion  = "refusing to drop stored version of CustomResourceDefinition"
+	errNotOurCRD          = "refusing to manage a CustomResourceDefinition that was not created by this managed resource"
+)
+
+// crdGroupKind is the group and kind of a CustomResourceDefinition.
+var crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+// SetupRemoteCRDBundle adds a controller that reconciles RemoteCRDBundle
+// managed resources.
+func SetupRemoteCRDBundle(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteCRDBundleGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteCRDBundle{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteCRDBundleGroupVersionKind),
+			managed.WithExternalConnecter(&crdBundleConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type crdBundleConnector struct {
+	kube client.Client
+}
+
+func (c *crdBundleConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteCRDBundle)
+	if !ok {
+		return nil, errors.New(errNotCRDBundle)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &crdBundleExternal{remote: remote}, nil
+}
+
+type crdBundleExternal struct {
+	remote client.Client
+}
+
+func (e *crdBundleExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteCRDBundle)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCRDBundle)
+	}
+
+	desired, err := desiredCRDs(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	// We consider the bundle to exist if any of its CustomResourceDefinitions
+	// exist, so that a partially applied bundle is updated rather than created
+	// and a partially deleted bundle is deleted again. A
+	// CustomResourceDefinition we did not create is never adopted; a deleted
+	// bundle considers it not to exist, so that it is left alone.
+	exists, upToDate, established := false, true, true
+	observations := make([]v1alpha1.CRDStatus, 0, len(desired))
+	for _, o := range desired {
+		observed, err := e.get(ctx, o)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if observed == nil {
+			upToDate, established = false, false
+			continue
+		}
+		if !ownedBy(observed, cr) {
+			if meta.WasDeleted(cr) {
+				continue
+			}
+			return managed.ExternalObservation{}, errors.Errorf("%s: %s", errNotOurCRD, observed.GetName())
+		}
+		exists = true
+
+		s, err := crdStatus(observed)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		observations = append(observations, s)
+		established = established && s.Established
+		if !isSubset(o.Object, observed.Object) {
+			upToDate = false
+		}
+	}
+	cr.Status.AtProvider.CustomResourceDefinitions = observations
+
+	if !exists {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	switch {
+	case meta.WasDeleted(cr):
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	case established:
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	default:
+		cr.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (e *crdBundleExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteCRDBundle)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCRDBundle)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *crdBundleExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteCRDBundle)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCRDBundle)
+	}
+
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *crdBundleExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteCRDBundle)
+	if !ok {
+		return errors.New(errNotCRDBundle)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	desired, err := desiredCRDs(cr)
+	if err != nil {
+		return err
+	}
+	for _, o := range desired {
+		if err := deleteOwned(ctx, e.remote, o, cr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// apply the CustomResourceDefinitions of the supplied RemoteCRDBundle. None
+// are applied if any would drop a version that has been stored; the objects
+// stored at that version would otherwise become unreadable.
+func (e *crdBundleExternal) apply(ctx context.Context, cr *v1alpha1.RemoteCRDBundle) error {
+	desired, err := desiredCRDs(cr)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range desired {
+		observed, err := e.get(ctx, o)
+		if err != nil {
+			return err
+		}
+		if observed == nil {
+			continue
+		}
+		if !ownedBy(observed, cr) {
+			return errors.Errorf("%s: %s", errNotOurCRD, observed.GetName())
+		}
+		s, err := crdStatus(observed)
+		if err != nil {
+			return err
+		}
+		if err := checkStoredVersions(o, s.StoredVersions); err != nil {
+			return err
+		}
+	}
+
+	for _, o := range desired {
+		if err := apply(ctx, e.remote, o); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// get the observed state of the supplied CustomResourceDefinition. It returns
+// nil if the CustomResourceDefinition does not exist.
+func (e *crdBundleExternal) get(ctx context.Context, o *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	observed := &unstructured.Unstructured{}
+	observed.SetGroupVersionKind(o.GroupVersionKind())
+	err := e.remote.Get(ctx, types.NamespacedName{Name: o.GetName()}, observed)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return observed, errors.Wrap(err, errGetCRD)
+}
+
+// desiredCRDs returns the CustomResourceDefinitions described by the manifests
+// of the supplied RemoteCRDBundle, marked as created by it.
+func desiredCRDs(cr *v1alpha1.RemoteCRDBundle) ([]*unstructured.Unstructured, error) {
+	crds := make([]*unstructured.Unstructured, 0, len(cr.Spec.ForProvider.CustomResourceDefinitions))
+	for _, m := range cr.Spec.ForProvider.CustomResourceDefinitions {
+		o := &unstructured.Unstructured{}
+		if err := o.UnmarshalJSON(m.Manifest.Raw); err != nil {
+			return nil, errors.Wrap(err, errDecodeObject)
+		}
+		if o.GroupVersionKind().GroupKind() != crdGroupKind {
+			return nil, errors.New(errNotCRD)
+		}
+		if o.GetName() == "" {
+			return nil, errors.New(errNoCRDName)
+		}
+		o.SetLabels(managedLabels(o.GetLabels()))
+		o.SetAnnotations(ownerAnnotations(o.GetAnnotations(), cr))
+		crds = append(crds, o)
+	}
+	return crds, nil
+}
+
+// crdVersions returns the set of versions specified by the supplied
+// CustomResourceDefinition manifest. The deprecated spec.version field of
+// apiextensions.k8s.io/v1beta1 is honoured.
+func crdVersions(o *unstructured.Unstructured) map[string]bool {
+	versions := map[string]bool{}
+	if v, _, _ := unstructured.NestedString(o.Object, "spec", "version"); v != "" {
+		versions[v] = true
+	}
+	vs, _, _ := unstructured.NestedSlice(o.Object, "spec", "versions")
+	for _, v := range vs {
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := m["name"].(string); ok {
+			versions[name] = true
+		}
+	}
+	return versions
+}
+
+// checkStoredVersions returns an error if the supplied CustomResourceDefinition
+// manifest does not specify all of the supplied stored versions.
+func checkStoredVersions(o *unstructured.Unstructured, stored []string) error {
+	versions := crdVersions(o)
+	for _, v := range stored {
+		if !versions[v] {
+			return errors.Errorf("%s %s: %s", errDropStoredVersion, o.GetName(), v)
+		}
+	}
+	return nil
+}
+
+// crdStatus returns the status of the supplied observed
+// CustomResourceDefinition.
+func crdStatus(o *unstructured.Unstructured) (v1alpha1.CRDStatus, error) {
+	established, err := hasCondition(o, &v1alpha1.ReadinessCheck{ConditionType: "Established"})
+	if err != nil {
+		return v1alpha1.CRDStatus{}, err
+	}
+	stored, _, err := unstructured.NestedStringSlice(o.Object, "status", "storedVersions")
+	if err != nil {
+		return v1alpha1.CRDStatus{}, errors.Wrap(err, errReadStoredVersions)
+	}
+	return v1alpha1.CRDStatus{Name: o.GetName(), Established: established, StoredVersions: stored}, nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/container"
+)
+
+func crd(spec map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1beta1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": "widgets.example.org"},
+		"spec":       spec,
+	}}
+}
+
+func TestCRDVersions(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      *unstructured.Unstructured
+		want   map[string]bool
+	}{
+		"NoVersions": {
+			reason: "A manifest that specifies no versions should have none.",
+			o:      crd(map[string]interface{}{}),
+			want:   map[string]bool{},
+		},
+		"Version": {
+			reason: "The deprecated spec.version field should be honoured.",
+			o:      crd(map[string]interface{}{"version": "v1alpha1"}),
+			want:   map[string]bool{"v1alpha1": true},
+		},
+		"Versions": {
+			reason: "Every version in spec.versions should be returned.",
+			o: crd(map[string]interface{}{"versions": []interface{}{
+				map[string]interface{}{"name": "v1alpha1"},
+				map[string]interface{}{"name": "v1beta1"},
+			}}),
+			want: map[string]bool{"v1alpha1": true, "v1beta1": true},
+		},
+		"VersionAndVersions": {
+			reason: "spec.version and spec.versions should be combined.",
+			o: crd(map[string]interface{}{
+				"version":  "v1alpha1",
+				"versions": []interface{}{map[string]interface{}{"name": "v1beta1"}},
+			}),
+			want: map[string]bool{"v1alpha1": true, "v1beta1": true},
+		},
+		"MalformedVersion": {
+			reason: "Entries of spec.versions that are not objects should be ignored.",
+			o:      crd(map[string]interface{}{"versions": []interface{}{"v1alpha1", map[string]interface{}{"name": "v1beta1"}}}),
+			want:   map[string]bool{"v1beta1": true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := crdVersions(tc.o)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ncrdVersions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheckStoredVersions(t *testing.T) {
+	o := crd(map[string]interface{}{"versions": []interface{}{
+		map[string]interface{}{"name": "v1beta1"},
+		map[string]interface{}{"name": "v1"},
+	}})
+
+	cases := map[string]struct {
+		reason string
+		stored []string
+		want   error
+	}{
+		"NothingStored": {
+			reason: "A CustomResourceDefinition that has stored nothing should accept any versions.",
+		},
+		"AllStoredVersionsKept": {
+			reason: "A manifest that keeps every stored version should be accepted.",
+			stored: []string{"v1beta1", "v1"},
+		},
+		"StoredVersionDropped": {
+			reason: "A manifest that drops a stored version should be refused.",
+			stored: []string{"v1alpha1", "v1beta1"},
+			want:   errors.Errorf("%s %s: %s", errDropStoredVersion, "widgets.example.org", "v1alpha1"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := checkStoredVersions(o, tc.stored)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ncheckStoredVersions(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCRDBundleOwnership(t *testing.T) {
+	uid := types.UID("cool-uid")
+
+	// withOwner returns a MockGetFn that returns a CustomResourceDefinition
+	// labelled and annotated as created by the supplied UID, if any.
+	withOwner := func(owner types.UID) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o runtime.Object) error {
+			u := o.(*unstructured.Unstructured)
+			u.SetName("widgets.example.org")
+			u.SetUID("crd-uid")
+			if owner != "" {
+				u.SetLabels(map[string]string{container.LabelKeyManagedBy: container.LabelValueManagedBy})
+				u.SetAnnotations(map[string]string{v1alpha1.AnnotationKeyOwner: string(owner)})
+			}
+			return nil
+		})
+	}
+
+	newBundle := func(deleted bool) *v1alpha1.RemoteCRDBundle {
+		cr := &v1alpha1.RemoteCRDBundle{ObjectMeta: metav1.ObjectMeta{UID: uid}}
+		if deleted {
+			now := metav1.Now()
+			cr.SetDeletionTimestamp(&now)
+		}
+		raw, _ := crd(map[string]interface{}{"version": "v1"}).MarshalJSON()
+		cr.Spec.ForProvider.CustomResourceDefinitions = []v1alpha1.CRDManifest{{Manifest: runtime.RawExtension{Raw: raw}}}
+		return cr
+	}
+
+	type want struct {
+		exists     bool
+		observeErr error
+		deleted    bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		get    test.MockGetFn
+		cr     *v1alpha1.RemoteCRDBundle
+		want   want
+	}{
+		"Owned": {
+			reason: "A CustomResourceDefinition we created should exist and be deleted.",
+			get:    withOwner(uid),
+			cr:     newBundle(false),
+			want:   want{exists: true, deleted: true},
+		},
+		"NotOurs": {
+			reason: "A CustomResourceDefinition we did not create should not be adopted or deleted.",
+			get:    withOwner(""),
+			cr:     newBundle(false),
+			want:   want{observeErr: errors.Errorf("%s: %s", errNotOurCRD, "widgets.example.org")},
+		},
+		"OtherOwner": {
+			reason: "A CustomResourceDefinition created by another bundle should not be adopted or deleted.",
+			get:    withOwner("other-uid"),
+			cr:     newBundle(false),
+			want:   want{observeErr: errors.Errorf("%s: %s", errNotOurCRD, "widgets.example.org")},
+		},
+		"NotOursDeleted": {
+			reason: "A deleted bundle should consider a CustomResourceDefinition it did not create not to exist.",
+			get:    withOwner(""),
+			cr:     newBundle(true),
+			want:   want{exists: false},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			deleted := false
+			e := &crdBundleExternal{remote: &test.MockClient{
+				MockGet: tc.get,
+				MockDelete: func(_ context.Context, _ runtime.Object, _ ...client.DeleteOption) error {
+					deleted = true
+					return nil
+				},
+			}}
+
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.observeErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want exists, +got exists:\n%s", tc.reason, diff)
+			}
+
+			if err := e.Delete(context.Background(), tc.cr); err != nil {
+				t.Errorf("\n%s\ne.Delete(...): %s", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want.deleted, deleted); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want deleted, +got deleted:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,333 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"helm.sh/helm/v3/pkg/chart"
+	"helm.sh/helm/v3/pkg/release"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/helm"
+)
+
+// Error strings.
+const (
+	errNotHelmRelease   = "managed resource is not a RemoteHelmRelease"
+	errGetKubeconfig    = "cannot get kubeconfig of referenced ExistingCluster"
+	errNewHelmClient    = "cannot create Helm client"
+	errNoChartSource    = "chart must specify a configMapRef, secretRef, or repository"
+	errGetChartMap      = "cannot get chart ConfigMap"
+	errGetChartSecret   = "cannot get chart Secret"
+	errGetValuesSecret  = "cannot get values Secret"
+	errNoConfigMapKey   = "ConfigMap has no such key"
+	errNoSecretKey      = "Secret has no such key"
+	errResolveValues    = "cannot resolve values"
+	errResolveChart     = "cannot resolve chart"
+	errLoadReleaseChart = "cannot load chart"
+	errNotOurRelease    = "refusing to manage a release that was not installed by this managed resource; set adoptExisting to adopt it"
+)
+
+// SetupRemoteHelmRelease adds a controller that reconciles RemoteHelmRelease
+// managed resources.
+func SetupRemoteHelmRelease(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteHelmReleaseGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteHelmRelease{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteHelmReleaseGroupVersionKind),
+			managed.WithExternalConnecter(&helmReleaseConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type helmReleaseConnector struct {
+	kube client.Client
+}
+
+func (c *helmReleaseConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteHelmRelease)
+	if !ok {
+		return nil, errors.New(errNotHelmRelease)
+	}
+
+	p, err := providerFor(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	kc, err := cluster.GetKubeconfig(ctx, c.kube, p)
+	if err != nil {
+		return orphan(cr, errors.Wrap(err, errGetKubeconfig))
+	}
+	h, err := helm.NewClient(kc, cr.Spec.ForProvider.Namespace)
+	if err != nil {
+		return nil, errors.Wrap(err, errNewHelmClient)
+	}
+	return &helmReleaseExternal{kube: c.kube, helm: h}, nil
+}
+
+type helmReleaseExternal struct {
+	kube client.Client
+	helm *helm.Client
+}
+
+func (e *helmReleaseExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteHelmRelease)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotHelmRelease)
+	}
+
+	rel, err := e.helm.Get(meta.GetExternalName(cr))
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if rel == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// A release we did not install is only adopted if we're asked to. A
+	// deleted resource observes that it does not exist, so that its
+	// finalizer is removed without the release being uninstalled.
+	owned := cr.Status.AtProvider.Owned || cr.Spec.ForProvider.AdoptExisting
+	if !owned {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errNotOurRelease)
+	}
+
+	cr.Status.AtProvider = v1alpha1.RemoteHelmReleaseObservation{
+		Revision: rel.Version,
+		Status:   rel.Info.Status.String(),
+		Owned:    owned,
+	}
+	if md := rel.Chart.Metadata; md != nil {
+		cr.Status.AtProvider.ChartName = md.Name
+		cr.Status.AtProvider.ChartVersion = md.Version
+		cr.Status.AtProvider.AppVersion = md.AppVersion
+	}
+
+	switch rel.Info.Status {
+	case release.StatusDeployed:
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	case release.StatusUninstalling:
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	default:
+		cr.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	// The chart and values need not resolve while we're being deleted; we
+	// only need to know whether the release remains.
+	if meta.WasDeleted(cr) {
+		cr.SetConditions(runtimev1alpha1.Deleting())
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	// Helm refuses to operate on a release while another operation on it is
+	// in progress, so we wait for it to complete.
+	if helm.InProgress(rel) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	md, _, err := e.chart(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveChart)
+	}
+	values, err := e.values(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errResolveValues)
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists: true,
+		ResourceUpToDate: rel.Info.Status == release.StatusDeployed &&
+			cr.Status.AtProvider.ChartName == md.Name &&
+			cr.Status.AtProvider.ChartVersion == md.Version &&
+			valuesEqual(rel.Config, values),
+	}, nil
+}
+
+func (e *helmReleaseExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteHelmRelease)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotHelmRelease)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	ch, values, err := e.load(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// We observed that the release did not exist, so any release of this
+	// name was installed by us, even if the install fails.
+	cr.Status.AtProvider.Owned = true
+	_, err = e.helm.Install(meta.GetExternalName(cr), ch, values)
+	return managed.ExternalCreation{}, err
+}
+
+func (e *helmReleaseExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteHelmRelease)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotHelmRelease)
+	}
+
+	ch, values, err := e.load(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, err = e.helm.Upgrade(meta.GetExternalName(cr), ch, values)
+	return managed.ExternalUpdate{}, err
+}
+
+func (e *helmReleaseExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteHelmRelease)
+	if !ok {
+		return errors.New(errNotHelmRelease)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	return e.helm.Uninstall(meta.GetExternalName(cr))
+}
+
+// load the chart and values of the supplied RemoteHelmRelease.
+func (e *helmReleaseExternal) load(ctx context.Context, cr *v1alpha1.RemoteHelmRelease) (*chart.Chart, map[string]interface{}, error) {
+	_, load, err := e.chart(ctx, cr)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errResolveChart)
+	}
+	ch, err := load()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, errLoadReleaseChart)
+	}
+	values, err := e.values(ctx, cr)
+	return ch, values, errors.Wrap(err, errResolveValues)
+}
+
+// chart returns the metadata of the chart of the supplied RemoteHelmRelease,
+// and a function that loads the chart. Charts are only downloaded from a
+// repository when they are loaded.
+func (e *helmReleaseExternal) chart(ctx context.Context, cr *v1alpha1.RemoteHelmRelease) (*chart.Metadata, func() (*chart.Chart, error), error) {
+	src := cr.Spec.ForProvider.Chart
+	switch {
+	case src.ConfigMapRef != nil:
+		b, err := getConfigMapKey(ctx, e.kube, *src.ConfigMapRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		return loaded(helm.LoadArchive(b))
+	case src.SecretRef != nil:
+		b, err := getSecretKey(ctx, e.kube, *src.SecretRef)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, errGetChartSecret)
+		}
+		return loaded(helm.LoadArchive(b))
+	case src.Repository != nil:
+		r := src.Repository
+		index, err := getConfigMapKey(ctx, e.kube, r.IndexRef)
+		if err != nil {
+			return nil, nil, err
+		}
+		cv, err := helm.FindInIndex(index, r.Name, r.Version)
+		if err != nil {
+			return nil, nil, err
+		}
+		return cv.Metadata, func() (*chart.Chart, error) { return helm.Download(cv, r.URL) }, nil
+	default:
+		return nil, nil, errors.New(errNoChartSource)
+	}
+}
+
+// loaded adapts a chart that has already been loaded to the signature of
+// helmReleaseExternal.chart.
+func loaded(ch *chart.Chart, err error) (*chart.Metadata, func() (*chart.Chart, error), error) {
+	if err != nil {
+		return nil, nil, err
+	}
+	return ch.Metadata, func() (*chart.Chart, error) { return ch, nil }, nil
+}
+
+// values returns the values of the supplied RemoteHelmRelease. Values read
+// from secrets are merged over inline values, in order.
+func (e *helmReleaseExternal) values(ctx context.Context, cr *v1alpha1.RemoteHelmRelease) (map[string]interface{}, error) {
+	docs := [][]byte{[]byte(cr.Spec.ForProvider.Values)}
+	for _, sel := range cr.Spec.ForProvider.ValuesFrom {
+		b, err := getSecretKey(ctx, e.kube, sel)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetValuesSecret)
+		}
+		docs = append(docs, b)
+	}
+	return helm.MergeValues(docs...)
+}
+
+// valuesEqual returns true if the supplied values are equal. No values are
+// equal to empty values.
+func valuesEqual(a, b map[string]interface{}) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// getConfigMapKey returns the value of the ConfigMap key selected by the
+// supplied selector, from either its data or its binaryData.
+func getConfigMapKey(ctx context.Context, kube client.Reader, sel v1alpha1.ConfigMapKeySelector) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, cm); err != nil {
+		return nil, errors.Wrap(err, errGetChartMap)
+	}
+	if b, ok := cm.BinaryData[sel.Key]; ok {
+		return b, nil
+	}
+	if s, ok := cm.Data[sel.Key]; ok {
+		return []byte(s), nil
+	}
+	return nil, errors.Errorf("%s: %s", errNoConfigMapKey, sel.Key)
+}
+
+// getSecretKey returns the value of the Secret key selected by the supplied
+// selector.
+func getSecretKey(ctx context.Context, kube client.Reader, sel runtimev1alpha1.SecretKeySelector) ([]byte, error) {
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: sel.Namespace, Name: sel.Name}, s); err != nil {
+		return nil, err
+	}
+	b, ok := s.Data[sel.Key]
+	if !ok {
+		return nil, errors.Errorf("%s: %s", errNoSecretKey, sel.Key)
+	}
+	return b, nil
+}
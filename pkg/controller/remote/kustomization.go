@@ -0,0 +1,232 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"path"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/kustomize"
+)
+
+// Error strings.
+const (
+	errNotKustomization = "managed resource is not a RemoteKustomization"
+	errGetFiles         = "cannot get kustomization files ConfigMap"
+	errGetInventory     = "cannot get object of inventory"
+	errPruneObject      = "cannot prune object"
+)
+
+// SetupRemoteKustomization adds a controller that reconciles
+// RemoteKustomization managed resources.
+func SetupRemoteKustomization(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteKustomizationGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteKustomization{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteKustomizationGroupVersionKind),
+			managed.WithExternalConnecter(&kustomizationConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type kustomizationConnector struct {
+	kube client.Client
+}
+
+func (c *kustomizationConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteKustomization)
+	if !ok {
+		return nil, errors.New(errNotKustomization)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &kustomizationExternal{kube: c.kube, remote: remote}, nil
+}
+
+type kustomizationExternal struct {
+	kube   client.Client
+	remote client.Client
+}
+
+func (e *kustomizationExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteKustomization)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotKustomization)
+	}
+
+	// The kustomization need not render while we're being deleted; we only
+	// need to know whether any object of our inventory remains.
+	if meta.WasDeleted(cr) {
+		exists, err := e.anyExist(ctx, cr.Status.AtProvider.Inventory)
+		return managed.ExternalObservation{ResourceExists: exists}, err
+	}
+
+	// We consider the kustomization to exist once it has been applied, even
+	// if it rendered no objects.
+	if cr.Status.AtProvider.LastAppliedTime == nil && len(cr.Status.AtProvider.Inventory) == 0 {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	desired, err := e.render(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	upToDate := inventoryEqual(cr.Status.AtProvider.Inventory, desired)
+	available := true
+	for _, o := range desired {
+		observed := &unstructured.Unstructured{}
+		observed.SetGroupVersionKind(o.GroupVersionKind())
+		err := e.remote.Get(ctx, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}, observed)
+		if kerrors.IsNotFound(err) {
+			upToDate, available = false, false
+			continue
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+		}
+		if !isSubset(o.Object, observed.Object) {
+			upToDate = false
+		}
+	}
+
+	if available {
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	} else {
+		cr.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate}, nil
+}
+
+func (e *kustomizationExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteKustomization)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotKustomization)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	return managed.ExternalCreation{}, e.sync(ctx, cr)
+}
+
+func (e *kustomizationExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteKustomization)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotKustomization)
+	}
+
+	return managed.ExternalUpdate{}, e.sync(ctx, cr)
+}
+
+func (e *kustomizationExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteKustomization)
+	if !ok {
+		return errors.New(errNotKustomization)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	return deleteAll(ctx, e.remote, cr.Status.AtProvider.Inventory, nil)
+}
+
+// sync applies the objects rendered by the supplied RemoteKustomization, then
+// prunes any object of its inventory that was not rendered. The inventory is
+// updated to record the objects that were applied.
+func (e *kustomizationExternal) sync(ctx context.Context, cr *v1alpha1.RemoteKustomization) error {
+	desired, err := e.render(ctx, cr)
+	if err != nil {
+		return err
+	}
+
+	previous := cr.Status.AtProvider.Inventory
+	applied := make([]v1alpha1.ObjectReference, 0, len(desired))
+	for _, o := range desired {
+		if err := apply(ctx, e.remote, o); err != nil {
+			// We must not forget the objects of our previous inventory,
+			// which we've yet to prune.
+			cr.Status.AtProvider.Inventory = union(previous, applied)
+			return err
+		}
+		applied = append(applied, referenceTo(o))
+	}
+
+	if err := deleteAll(ctx, e.remote, previous, applied); err != nil {
+		cr.Status.AtProvider.Inventory = union(previous, applied)
+		return errors.Wrap(err, errPruneObject)
+	}
+
+	now := metav1.Now()
+	cr.Status.AtProvider.Inventory = applied
+	cr.Status.AtProvider.LastAppliedTime = &now
+	return nil
+}
+
+// render the kustomization of the supplied RemoteKustomization.
+func (e *kustomizationExternal) render(ctx context.Context, cr *v1alpha1.RemoteKustomization) ([]*unstructured.Unstructured, error) {
+	files := kustomize.Files{}
+	for _, f := range cr.Spec.ForProvider.Files {
+		cm := &corev1.ConfigMap{}
+		nn := types.NamespacedName{Namespace: f.ConfigMapRef.Namespace, Name: f.ConfigMapRef.Name}
+		if err := e.kube.Get(ctx, nn, cm); err != nil {
+			return nil, errors.Wrap(err, errGetFiles)
+		}
+		for k, v := range cm.Data {
+			files[path.Join(f.Path, k)] = []byte(v)
+		}
+		for k, v := range cm.BinaryData {
+			files[path.Join(f.Path, k)] = v
+		}
+	}
+	return kustomize.Render(files, cr.Spec.ForProvider.Path)
+}
+
+// anyExist returns true if any of the referenced objects exist.
+func (e *kustomizationExternal) anyExist(ctx context.Context, refs []v1alpha1.ObjectReference) (bool, error) {
+	for _, r := range refs {
+		err := e.remote.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.Name}, objectFor(r))
+		if err == nil {
+			return true, nil
+		}
+		if !kerrors.IsNotFound(err) {
+			return false, errors.Wrap(err, errGetInventory)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// Error strings.
+const (
+	errNotNamespace    = "managed resource is not a RemoteNamespace"
+	errGetNamespace    = "cannot get namespace"
+	errCreateNamespace = "cannot create namespace"
+	errUpdateNamespace = "cannot update namespace"
+	errDeleteNamespace = "cannot delete namespace"
+	errNotOurNamespace = "refusing to manage a namespace that was not created by this managed resource"
+)
+
+// SetupRemoteNamespace adds a controller that reconciles RemoteNamespace
+// managed resources.
+func SetupRemoteNamespace(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteNamespaceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteNamespace{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteNamespaceGroupVersionKind),
+			managed.WithExternalConnecter(&namespaceConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type namespaceConnector struct {
+	kube client.Client
+}
+
+func (c *namespaceConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteNamespace)
+	if !ok {
+		return nil, errors.New(errNotNamespace)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &namespaceExternal{remote: remote}, nil
+}
+
+type namespaceExternal struct {
+	remote client.Client
+}
+
+func (e *namespaceExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteNamespace)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotNamespace)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := e.remote.Get(ctx, types.NamespacedName{Name: meta.GetExternalName(cr)}, ns); err != nil {
+		if kerrors.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetNamespace)
+	}
+
+	// A namespace we did not create is never adopted. A deleted resource
+	// observes that it does not exist, so that its finalizer is removed
+	// without the namespace being deleted.
+	if !ownedBy(ns, cr) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errNotOurNamespace)
+	}
+
+	cr.Status.AtProvider.Phase = string(ns.Status.Phase)
+	switch ns.Status.Phase {
+	case corev1.NamespaceActive:
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	case corev1.NamespaceTerminating:
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	}
+
+	p := cr.Spec.ForProvider
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: hasAll(ns.GetLabels(), p.Labels) && hasAll(ns.GetAnnotations(), p.Annotations),
+	}, nil
+}
+
+func (e *namespaceExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteNamespace)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotNamespace)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        meta.GetExternalName(cr),
+		Labels:      managedLabels(cr.Spec.ForProvider.Labels),
+		Annotations: ownerAnnotations(cr.Spec.ForProvider.Annotations, cr),
+	}}
+	return managed.ExternalCreation{}, errors.Wrap(e.remote.Create(ctx, ns), errCreateNamespace)
+}
+
+func (e *namespaceExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteNamespace)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotNamespace)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := e.remote.Get(ctx, types.NamespacedName{Name: meta.GetExternalName(cr)}, ns); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetNamespace)
+	}
+
+	ns.SetLabels(withAll(ns.GetLabels(), cr.Spec.ForProvider.Labels))
+	ns.SetAnnotations(withAll(ns.GetAnnotations(), cr.Spec.ForProvider.Annotations))
+	return managed.ExternalUpdate{}, errors.Wrap(e.remote.Update(ctx, ns), errUpdateNamespace)
+}
+
+func (e *namespaceExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteNamespace)
+	if !ok {
+		return errors.New(errNotNamespace)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	return deleteNamespace(ctx, e.remote, meta.GetExternalName(cr), cr)
+}
+
+// deleteNamespace deletes the named namespace, which must have been created by
+// the supplied managed resource.
+func deleteNamespace(ctx context.Context, remote client.Client, name string, owner metav1.Object) error {
+	ns := &corev1.Namespace{}
+	err := remote.Get(ctx, types.NamespacedName{Name: name}, ns)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetNamespace)
+	}
+	if !ownedBy(ns, owner) {
+		return errors.New(errNotOurNamespace)
+	}
+	return errors.Wrap(resource.IgnoreNotFound(remote.Delete(ctx, ns)), errDeleteNamespace)
+}
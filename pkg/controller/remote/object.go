@@ -0,0 +1,265 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// FieldManager is the server-side apply field manager of objects applied by
+// this provider.
+const FieldManager = "provider-existing-cluster"
+
+// Error strings.
+const (
+	errNotObject    = "managed resource is not a RemoteObject"
+	errNoManifest   = "manifest must specify apiVersion and kind"
+	errDecodeObject = "cannot decode manifest"
+	errGetObject    = "cannot get object"
+	errApplyObject  = "cannot apply object"
+	errDeleteObject = "cannot delete object"
+	errNotOurObject = "refusing to manage an object that was not created by this managed resource"
+)
+
+// SetupRemoteObject adds a controller that reconciles RemoteObject managed
+// resources.
+func SetupRemoteObject(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteObjectGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteObject{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteObjectGroupVersionKind),
+			managed.WithExternalConnecter(&objectConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type objectConnector struct {
+	kube client.Client
+}
+
+func (c *objectConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObject)
+	if !ok {
+		return nil, errors.New(errNotObject)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &objectExternal{remote: remote}, nil
+}
+
+type objectExternal struct {
+	remote client.Client
+}
+
+func (e *objectExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObject)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotObject)
+	}
+
+	desired, err := desiredObject(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed := &unstructured.Unstructured{}
+	observed.SetGroupVersionKind(desired.GroupVersionKind())
+	nn := types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}
+	if err := e.remote.Get(ctx, nn, observed); err != nil {
+		if kerrors.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+	}
+
+	// An object we did not create is never adopted. A deleted resource
+	// observes that it does not exist, so that its finalizer is removed
+	// without the object being deleted.
+	if !ownedBy(observed, cr) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errNotOurObject)
+	}
+
+	ready, err := isReady(observed, cr.Spec.ForProvider.Readiness)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider.UID = string(observed.GetUID())
+	cr.Status.AtProvider.ResourceVersion = observed.GetResourceVersion()
+	cr.Status.AtProvider.Ready = ready
+	switch {
+	case observed.GetDeletionTimestamp() != nil:
+		cr.SetConditions(runtimev1alpha1.Deleting())
+	case ready:
+		// We only report availability once the object is ready, so that
+		// anything waiting on this RemoteObject waits on the object too.
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	default:
+		cr.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: isSubset(desired.Object, observed.Object),
+	}, nil
+}
+
+func (e *objectExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObject)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotObject)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	return managed.ExternalCreation{}, e.apply(ctx, cr)
+}
+
+func (e *objectExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObject)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotObject)
+	}
+
+	return managed.ExternalUpdate{}, e.apply(ctx, cr)
+}
+
+func (e *objectExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteObject)
+	if !ok {
+		return errors.New(errNotObject)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	o, err := desiredObject(cr)
+	if err != nil {
+		return err
+	}
+	if err := deleteOwned(ctx, e.remote, o, cr); err != nil {
+		return err
+	}
+	if prev := cr.Status.AtProvider.Object; prev != nil && *prev != referenceTo(o) {
+		return deleteOwned(ctx, e.remote, objectFor(*prev), cr)
+	}
+	return nil
+}
+
+// apply the manifest of the supplied RemoteObject. The object that was last
+// applied is deleted if the manifest now describes a different object.
+func (e *objectExternal) apply(ctx context.Context, cr *v1alpha1.RemoteObject) error {
+	o, err := desiredObject(cr)
+	if err != nil {
+		return err
+	}
+	if err := apply(ctx, e.remote, o); err != nil {
+		return err
+	}
+
+	ref := referenceTo(o)
+	if prev := cr.Status.AtProvider.Object; prev != nil && *prev != ref {
+		if err := deleteOwned(ctx, e.remote, objectFor(*prev), cr); err != nil {
+			return err
+		}
+	}
+	cr.Status.AtProvider.Object = &ref
+	return nil
+}
+
+// apply the supplied object using server-side apply. We force ownership of
+// conflicting fields in order to correct drift introduced by other field
+// managers.
+func apply(ctx context.Context, c client.Client, o *unstructured.Unstructured) error {
+	err := c.Patch(ctx, o, client.Apply, client.FieldOwner(FieldManager), client.ForceOwnership)
+	return errors.Wrap(err, errApplyObject)
+}
+
+// desiredObject returns the object described by the manifest of the supplied
+// RemoteObject, marked as created by it.
+func desiredObject(cr *v1alpha1.RemoteObject) (*unstructured.Unstructured, error) {
+	o := &unstructured.Unstructured{}
+	if err := o.UnmarshalJSON(cr.Spec.ForProvider.Manifest.Raw); err != nil {
+		return nil, errors.Wrap(err, errDecodeObject)
+	}
+	if o.GetAPIVersion() == "" || o.GetKind() == "" {
+		return nil, errors.New(errNoManifest)
+	}
+	if o.GetName() == "" {
+		o.SetName(meta.GetExternalName(cr))
+	}
+	o.SetLabels(managedLabels(o.GetLabels()))
+	o.SetAnnotations(ownerAnnotations(o.GetAnnotations(), cr))
+	return o, nil
+}
+
+// isSubset returns true if every field of the supplied desired value is set to
+// the same value in the supplied observed value. Fields that are only set in
+// the observed value, for example because they were defaulted, are ignored.
+// Lists must be of the same length.
+func isSubset(desired, observed interface{}) bool {
+	switch d := desired.(type) {
+	case map[string]interface{}:
+		o, ok := observed.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range d {
+			if !isSubset(v, o[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		o, ok := observed.([]interface{})
+		if !ok || len(d) != len(o) {
+			return false
+		}
+		for i := range d {
+			if !isSubset(d[i], o[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, observed)
+	}
+}
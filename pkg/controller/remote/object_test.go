@@ -0,0 +1,93 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"testing"
+)
+
+func TestIsSubset(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		desired  interface{}
+		observed interface{}
+		want     bool
+	}{
+		"Equal": {
+			reason:   "Identical values should be a subset of one another.",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			observed: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			want:     true,
+		},
+		"ObservedHasMoreFields": {
+			reason:   "Fields that are only set in the observed value should be ignored.",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			observed: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1), "paused": false}, "status": map[string]interface{}{}},
+			want:     true,
+		},
+		"NestedValueDiffers": {
+			reason:   "A nested field with a different observed value should not be a subset.",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{"image": "a"}}},
+			observed: map[string]interface{}{"spec": map[string]interface{}{"template": map[string]interface{}{"image": "b"}}},
+			want:     false,
+		},
+		"NestedFieldMissing": {
+			reason:   "A desired field that is not observed should not be a subset.",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			observed: map[string]interface{}{"spec": map[string]interface{}{}},
+			want:     false,
+		},
+		"TypeDiffers": {
+			reason:   "A desired map whose observed value is not a map should not be a subset.",
+			desired:  map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			observed: map[string]interface{}{"spec": "replicas"},
+			want:     false,
+		},
+		"ListElementsAreSubsets": {
+			reason:   "Lists should be compared element by element.",
+			desired:  []interface{}{map[string]interface{}{"name": "a"}},
+			observed: []interface{}{map[string]interface{}{"name": "a", "protocol": "TCP"}},
+			want:     true,
+		},
+		"ListLengthDiffers": {
+			reason:   "Lists of different lengths should not be a subset.",
+			desired:  []interface{}{"a"},
+			observed: []interface{}{"a", "b"},
+			want:     false,
+		},
+		"ListOrderDiffers": {
+			reason:   "Lists whose elements are in a different order should not be a subset.",
+			desired:  []interface{}{"a", "b"},
+			observed: []interface{}{"b", "a"},
+			want:     false,
+		},
+		"NilDesired": {
+			reason:   "A desired null should only match an unset or null observed value.",
+			desired:  map[string]interface{}{"spec": nil},
+			observed: map[string]interface{}{"spec": map[string]interface{}{}},
+			want:     false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := isSubset(tc.desired, tc.observed); got != tc.want {
+				t.Errorf("\n%s\nisSubset(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// Error strings.
+const (
+	errNotObjectImport  = "managed resource is not a RemoteObjectImport"
+	errGetImportObject  = "cannot get imported object"
+	errNoImportObject   = "imported object does not exist"
+	errDecodeField      = "cannot base64 decode imported field"
+	errObserveOnly      = "imported objects are observed, and never created"
+	errImportFieldValue = "cannot import field"
+)
+
+// SetupRemoteObjectImport adds a controller that reconciles RemoteObjectImport
+// managed resources.
+func SetupRemoteObjectImport(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteObjectImportGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteObjectImport{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteObjectImportGroupVersionKind),
+			managed.WithExternalConnecter(&objectImportConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type objectImportConnector struct {
+	kube client.Client
+}
+
+func (c *objectImportConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObjectImport)
+	if !ok {
+		return nil, errors.New(errNotObjectImport)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &objectImportExternal{remote: remote}, nil
+}
+
+// An objectImportExternal only ever reads from the remote cluster.
+type objectImportExternal struct {
+	remote client.Reader
+}
+
+func (e *objectImportExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteObjectImport)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotObjectImport)
+	}
+
+	// We never create or delete the imported object, so reporting that it
+	// does not exist once we're deleted allows our finalizer to be removed
+	// regardless of our reclaim policy.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	ref := cr.Spec.ForProvider.ObjectReference
+	o := objectFor(ref)
+	if err := e.remote.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, o); err != nil {
+		if kerrors.IsNotFound(err) {
+			cr.SetConditions(runtimev1alpha1.Unavailable())
+			return managed.ExternalObservation{}, errors.New(errNoImportObject)
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetImportObject)
+	}
+
+	fields := map[string]string{}
+	conn := managed.ConnectionDetails{}
+	for _, f := range cr.Spec.ForProvider.Fields {
+		v, ok, err := fieldValue(o, f.FieldPath)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrapf(err, "%s %s", errImportFieldValue, f.Name)
+		}
+		if !ok {
+			continue
+		}
+		if f.Base64 {
+			b, err := base64.StdEncoding.DecodeString(v)
+			if err != nil {
+				return managed.ExternalObservation{}, errors.Wrapf(err, "%s %s", errDecodeField, f.Name)
+			}
+			v = string(b)
+		}
+		conn[f.Name] = []byte(v)
+		if !f.Sensitive {
+			fields[f.Name] = v
+		}
+	}
+
+	cr.Status.AtProvider.UID = string(o.GetUID())
+	cr.Status.AtProvider.ResourceVersion = o.GetResourceVersion()
+	cr.Status.AtProvider.Fields = fields
+	cr.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(cr)
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: conn,
+	}, nil
+}
+
+func (e *objectImportExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, errors.New(errObserveOnly)
+}
+
+func (e *objectImportExternal) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing; deleting a RemoteObjectImport never deletes the object
+// it imports from.
+func (e *objectImportExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/util/jsonpath"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// Error strings.
+const (
+	errNoConditionType   = "readiness check of type Condition must specify a conditionType"
+	errNoFieldPath       = "readiness check of type FieldValue must specify a fieldPath"
+	errParseFieldPath    = "cannot parse fieldPath"
+	errFindFieldPath     = "cannot find fieldPath"
+	errUnknownCheckType  = "unknown readiness check type"
+	errReadConditionList = "cannot read status conditions of object"
+)
+
+// isReady returns true if the supplied object passes the supplied readiness
+// check. An object that exists passes a nil readiness check.
+func isReady(o *unstructured.Unstructured, c *v1alpha1.ReadinessCheck) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+
+	switch c.Type {
+	case v1alpha1.ReadinessCheckExists, "":
+		return true, nil
+	case v1alpha1.ReadinessCheckCondition:
+		return hasCondition(o, c)
+	case v1alpha1.ReadinessCheckFieldValue:
+		return hasFieldValue(o, c)
+	default:
+		return false, errors.Errorf("%s: %s", errUnknownCheckType, c.Type)
+	}
+}
+
+// hasCondition returns true if the supplied object's status has a condition of
+// the type and status specified by the supplied readiness check.
+func hasCondition(o *unstructured.Unstructured, c *v1alpha1.ReadinessCheck) (bool, error) {
+	if c.ConditionType == "" {
+		return false, errors.New(errNoConditionType)
+	}
+	want := c.ConditionStatus
+	if want == "" {
+		want = corev1.ConditionTrue
+	}
+
+	conditions, _, err := unstructured.NestedSlice(o.Object, "status", "conditions")
+	if err != nil {
+		return false, errors.Wrap(err, errReadConditionList)
+	}
+	for _, v := range conditions {
+		cond, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if cond["type"] == c.ConditionType {
+			return cond["status"] == string(want), nil
+		}
+	}
+	return false, nil
+}
+
+// hasFieldValue returns true if the field of the supplied object at the JSONPath
+// specified by the supplied readiness check has the specified value. An object
+// that does not have the field does not pass the check.
+func hasFieldValue(o *unstructured.Unstructured, c *v1alpha1.ReadinessCheck) (bool, error) {
+	if c.FieldPath == "" {
+		return false, errors.New(errNoFieldPath)
+	}
+	v, ok, err := fieldValue(o, c.FieldPath)
+	return ok && v == c.Value, err
+}
+
+// fieldValue returns the value of the field of the supplied object at the
+// supplied JSONPath, formatted as a string. It returns false if the object does
+// not have the field.
+func fieldValue(o *unstructured.Unstructured, path string) (string, bool, error) {
+	jp := jsonpath.New("field").AllowMissingKeys(true)
+	if err := jp.Parse(path); err != nil {
+		return "", false, errors.Wrap(err, errParseFieldPath)
+	}
+	results, err := jp.FindResults(o.Object)
+	if err != nil {
+		return "", false, errors.Wrap(err, errFindFieldPath)
+	}
+	if len(results) == 0 || len(results[0]) == 0 {
+		return "", false, nil
+	}
+
+	v := results[0][0]
+	if !v.IsValid() || !v.CanInterface() {
+		return "", false, nil
+	}
+	return fmt.Sprint(v.Interface()), true, nil
+}
@@ -0,0 +1,237 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// deployment returns an object with the supplied status.
+func deployment(status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata":   map[string]interface{}{"name": "coolapp"},
+		"status":     status,
+	}}
+}
+
+func TestIsReady(t *testing.T) {
+	available := deployment(map[string]interface{}{
+		"replicas": int64(3),
+		"phase":    "Running",
+		"conditions": []interface{}{
+			map[string]interface{}{"type": "Progressing", "status": "True"},
+			map[string]interface{}{"type": "Available", "status": "False"},
+		},
+	})
+
+	type want struct {
+		ready bool
+		err   string
+	}
+
+	cases := map[string]struct {
+		reason string
+		o      *unstructured.Unstructured
+		c      *v1alpha1.ReadinessCheck
+		want   want
+	}{
+		"NoCheck": {
+			reason: "An object that exists should pass a nil readiness check.",
+			o:      available,
+			want:   want{ready: true},
+		},
+		"Exists": {
+			reason: "An object that exists should pass an Exists check.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckExists},
+			want:   want{ready: true},
+		},
+		"ConditionDefaultsToTrue": {
+			reason: "A Condition check without a status should require the condition to be True.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition, ConditionType: "Progressing"},
+			want:   want{ready: true},
+		},
+		"ConditionHasOtherStatus": {
+			reason: "A condition with a status other than the one required should fail a Condition check.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition, ConditionType: "Available"},
+			want:   want{ready: false},
+		},
+		"ConditionHasRequiredStatus": {
+			reason: "A condition with the required status should pass a Condition check.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition, ConditionType: "Available", ConditionStatus: corev1.ConditionFalse},
+			want:   want{ready: true},
+		},
+		"ConditionMissing": {
+			reason: "An object without the required condition should fail a Condition check.",
+			o:      deployment(map[string]interface{}{}),
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition, ConditionType: "Available"},
+			want:   want{ready: false},
+		},
+		"ConditionsNotAList": {
+			reason: "Status conditions that are not a list should be returned as an error.",
+			o:      deployment(map[string]interface{}{"conditions": "Available"}),
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition, ConditionType: "Available"},
+			want:   want{err: errReadConditionList},
+		},
+		"NoConditionType": {
+			reason: "A Condition check without a condition type should be returned as an error.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckCondition},
+			want:   want{err: errNoConditionType},
+		},
+		"FieldValueMatches": {
+			reason: "A field with the required value should pass a FieldValue check.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue, FieldPath: "{.status.phase}", Value: "Running"},
+			want:   want{ready: true},
+		},
+		"FieldValueFormatted": {
+			reason: "A field that is not a string should be compared as it is formatted.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue, FieldPath: "{.status.replicas}", Value: "3"},
+			want:   want{ready: true},
+		},
+		"FieldValueDiffers": {
+			reason: "A field with a different value should fail a FieldValue check.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue, FieldPath: "{.status.phase}", Value: "Pending"},
+			want:   want{ready: false},
+		},
+		"FieldMissing": {
+			reason: "An object without the field should fail a FieldValue check, even if the required value is empty.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue, FieldPath: "{.status.readyReplicas}"},
+			want:   want{ready: false},
+		},
+		"NoFieldPath": {
+			reason: "A FieldValue check without a field path should be returned as an error.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue},
+			want:   want{err: errNoFieldPath},
+		},
+		"InvalidFieldPath": {
+			reason: "A field path that cannot be parsed should be returned as an error.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: v1alpha1.ReadinessCheckFieldValue, FieldPath: "{.status.phase"},
+			want:   want{err: errParseFieldPath},
+		},
+		"UnknownType": {
+			reason: "A readiness check of an unknown type should be returned as an error.",
+			o:      available,
+			c:      &v1alpha1.ReadinessCheck{Type: "Eventually"},
+			want:   want{err: errUnknownCheckType},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ready, err := isReady(tc.o, tc.c)
+			if tc.want.err == "" && err != nil {
+				t.Fatalf("\n%s\nisReady(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.want.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.want.err)) {
+				t.Fatalf("\n%s\nisReady(...): want error %q, got %v", tc.reason, tc.want.err, err)
+			}
+			if ready != tc.want.ready {
+				t.Errorf("\n%s\nisReady(...): want %t, got %t", tc.reason, tc.want.ready, ready)
+			}
+		})
+	}
+}
+
+func TestFieldValue(t *testing.T) {
+	o := deployment(map[string]interface{}{
+		"phase":    "Running",
+		"replicas": int64(3),
+		"empty":    "",
+		"ports":    []interface{}{map[string]interface{}{"port": int64(80)}},
+	})
+
+	type want struct {
+		v   string
+		ok  bool
+		err string
+	}
+
+	cases := map[string]struct {
+		reason string
+		path   string
+		want   want
+	}{
+		"String": {
+			reason: "A string field should be returned as is.",
+			path:   "{.status.phase}",
+			want:   want{v: "Running", ok: true},
+		},
+		"Integer": {
+			reason: "An integer field should be formatted as a string.",
+			path:   "{.status.replicas}",
+			want:   want{v: "3", ok: true},
+		},
+		"EmptyString": {
+			reason: "A field that is set to the empty string should be found.",
+			path:   "{.status.empty}",
+			want:   want{v: "", ok: true},
+		},
+		"ListElement": {
+			reason: "A field of a list element should be found.",
+			path:   "{.status.ports[0].port}",
+			want:   want{v: "80", ok: true},
+		},
+		"Missing": {
+			reason: "A field that is not set should not be found.",
+			path:   "{.status.readyReplicas}",
+			want:   want{ok: false},
+		},
+		"MissingParent": {
+			reason: "A field whose parent is not set should not be found.",
+			path:   "{.spec.template.spec}",
+			want:   want{ok: false},
+		},
+		"Unparseable": {
+			reason: "A path that cannot be parsed should be returned as an error.",
+			path:   "{.status.phase",
+			want:   want{err: errParseFieldPath},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			v, ok, err := fieldValue(o, tc.path)
+			if tc.want.err == "" && err != nil {
+				t.Fatalf("\n%s\nfieldValue(...): unexpected error: %v", tc.reason, err)
+			}
+			if tc.want.err != "" && (err == nil || !strings.HasPrefix(err.Error(), tc.want.err)) {
+				t.Fatalf("\n%s\nfieldValue(...): want error %q, got %v", tc.reason, tc.want.err, err)
+			}
+			if v != tc.want.v || ok != tc.want.ok {
+				t.Errorf("\n%s\nfieldValue(...): want %q, %t, got %q, %t", tc.reason, tc.want.v, tc.want.ok, v, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package remote contains controllers for resources within the clusters
+// represented by ExistingClusters.
+package remote
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	containerv1beta1 "github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/apis/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+	"github.com/turkenh/provider-existing-cluster/pkg/controller/container"
+)
+
+// Error strings.
+const (
+	errNoClusterRef    = "managed resource does not reference an ExistingCluster"
+	errGetCluster      = "cannot get referenced ExistingCluster"
+	errClusterChanged  = "referenced ExistingCluster no longer connects to the cluster it was first observed to connect to"
+	errNoProvider      = "referenced ExistingCluster has no Provider"
+	errGetProvider     = "cannot get Provider of referenced ExistingCluster"
+	errConnectToRemote = "cannot connect to cluster"
+)
+
+// A RemoteResource is a managed resource within the cluster represented by an
+// ExistingCluster.
+type RemoteResource interface {
+	resource.Managed
+
+	GetClusterReference() *corev1.ObjectReference
+}
+
+// connect returns a client for the cluster represented by the ExistingCluster
+// the supplied managed resource references, using the ExistingCluster's
+// Provider.
+func connect(ctx context.Context, kube client.Client, mg RemoteResource) (client.Client, error) {
+	p, err := providerFor(ctx, kube, mg)
+	if err != nil {
+		return nil, err
+	}
+	c, err := cluster.ClientFor(ctx, kube, p)
+	return c, errors.Wrap(err, errConnectToRemote)
+}
+
+// providerFor returns the Provider of the ExistingCluster the supplied managed
+// resource references.
+func providerFor(ctx context.Context, kube client.Client, mg RemoteResource) (*v1beta1.Provider, error) {
+	ref := mg.GetClusterReference()
+	if ref == nil {
+		return nil, errors.New(errNoClusterRef)
+	}
+	return providerOf(ctx, kube, ref)
+}
+
+// providerOf returns the Provider of the referenced ExistingCluster.
+func providerOf(ctx context.Context, kube client.Client, ref *corev1.ObjectReference) (*v1beta1.Provider, error) {
+	ec := &containerv1beta1.ExistingCluster{}
+	if err := kube.Get(ctx, meta.NamespacedNameOf(ref), ec); err != nil {
+		return nil, errors.Wrap(err, errGetCluster)
+	}
+
+	// We must not manage resources in a cluster other than the one the
+	// ExistingCluster represents.
+	if ec.GetCondition(containerv1beta1.TypeClusterIdentity).Status == corev1.ConditionFalse {
+		return nil, errors.New(errClusterChanged)
+	}
+
+	if ec.Spec.ProviderReference == nil {
+		return nil, errors.New(errNoProvider)
+	}
+	p := &v1beta1.Provider{}
+	if err := kube.Get(ctx, meta.NamespacedNameOf(ec.Spec.ProviderReference), p); err != nil {
+		return nil, errors.Wrap(err, errGetProvider)
+	}
+	return p, nil
+}
+
+// orphan allows the deletion of a managed resource to proceed when the
+// ExistingCluster it references no longer exists and its reclaim policy does
+// not require the resource within the cluster to be deleted. Otherwise the
+// supplied error is returned, and the resource's finalizer is kept until the
+// ExistingCluster is restored.
+func orphan(mg RemoteResource, err error) (managed.ExternalClient, error) {
+	if !meta.WasDeleted(mg) || mg.GetReclaimPolicy() == runtimev1alpha1.ReclaimDelete || !kerrors.IsNotFound(errors.Cause(err)) {
+		return nil, err
+	}
+
+	// A NopClient observes that the external resource does not exist, which
+	// causes the managed reconciler to remove our finalizer.
+	return &managed.NopClient{}, nil
+}
+
+// hasAll returns true if the supplied map contains every key and value of the
+// supplied desired map.
+func hasAll(m, desired map[string]string) bool {
+	for k, v := range desired {
+		if got, ok := m[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
+// withAll returns the supplied map with every key and value of the supplied
+// desired map added.
+func withAll(m, desired map[string]string) map[string]string {
+	if len(desired) == 0 {
+		return m
+	}
+	if m == nil {
+		m = make(map[string]string, len(desired))
+	}
+	for k, v := range desired {
+		m[k] = v
+	}
+	return m
+}
+
+// isManaged returns true if the supplied object was created by this provider.
+func isManaged(o metav1.Object) bool {
+	return o.GetLabels()[container.LabelKeyManagedBy] == container.LabelValueManagedBy
+}
+
+// ownedBy returns true if the supplied object was created by the supplied
+// managed resource.
+func ownedBy(o metav1.Object, mg metav1.Object) bool {
+	return isManaged(o) && o.GetAnnotations()[v1alpha1.AnnotationKeyOwner] == string(mg.GetUID())
+}
+
+// ownerAnnotations returns a copy of the supplied annotations with the
+// annotation that marks an object as created by the supplied managed resource
+// added.
+func ownerAnnotations(a map[string]string, mg metav1.Object) map[string]string {
+	return withAll(withAll(nil, a), map[string]string{v1alpha1.AnnotationKeyOwner: string(mg.GetUID())})
+}
+
+// managedLabels returns a copy of the supplied labels with the label that marks
+// an object as created by this provider added.
+func managedLabels(l map[string]string) map[string]string {
+	return withAll(withAll(nil, l), map[string]string{container.LabelKeyManagedBy: container.LabelValueManagedBy})
+}
+
+// referenceTo returns a reference to the supplied object.
+func referenceTo(o *unstructured.Unstructured) v1alpha1.ObjectReference {
+	return v1alpha1.ObjectReference{
+		APIVersion: o.GetAPIVersion(),
+		Kind:       o.GetKind(),
+		Namespace:  o.GetNamespace(),
+		Name:       o.GetName(),
+	}
+}
+
+// objectFor returns an object with the identity of the supplied reference.
+func objectFor(r v1alpha1.ObjectReference) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion(r.APIVersion)
+	u.SetKind(r.Kind)
+	u.SetNamespace(r.Namespace)
+	u.SetName(r.Name)
+	return u
+}
+
+// deleteAll deletes the referenced objects, except those that are also
+// referenced by the supplied exceptions, in the reverse of the order in which
+// they are referenced. Objects that do not exist are ignored.
+func deleteAll(ctx context.Context, c client.Writer, refs, except []v1alpha1.ObjectReference) error {
+	keep := map[v1alpha1.ObjectReference]bool{}
+	for _, r := range except {
+		keep[r] = true
+	}
+	for i := len(refs) - 1; i >= 0; i-- {
+		if keep[refs[i]] {
+			continue
+		}
+		err := c.Delete(ctx, objectFor(refs[i]), client.PropagationPolicy(metav1.DeletePropagationBackground))
+		if resource.IgnoreNotFound(err) != nil {
+			return errors.Wrap(err, errDeleteObject)
+		}
+	}
+	return nil
+}
+
+// deleteOwned deletes the supplied object if it was created by the supplied
+// managed resource. Objects that do not exist, or that were not created by the
+// managed resource, are ignored.
+func deleteOwned(ctx context.Context, c client.Client, o *unstructured.Unstructured, owner metav1.Object) error {
+	observed := &unstructured.Unstructured{}
+	observed.SetGroupVersionKind(o.GroupVersionKind())
+	err := c.Get(ctx, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}, observed)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetObject)
+	}
+	if !ownedBy(observed, owner) {
+		return nil
+	}
+
+	uid := observed.GetUID()
+	err = c.Delete(ctx, observed, client.PropagationPolicy(metav1.DeletePropagationBackground), client.Preconditions{UID: &uid})
+	return errors.Wrap(resource.IgnoreNotFound(err), errDeleteObject)
+}
+
+// inventoryEqual returns true if the supplied inventory references exactly the
+// supplied objects, in order.
+func inventoryEqual(inventory []v1alpha1.ObjectReference, objs []*unstructured.Unstructured) bool {
+	if len(inventory) != len(objs) {
+		return false
+	}
+	for i := range objs {
+		if inventory[i] != referenceTo(objs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// union returns the references of a, followed by any references of b that are
+// not also references of a.
+func union(a, b []v1alpha1.ObjectReference) []v1alpha1.ObjectReference {
+	seen := map[v1alpha1.ObjectReference]bool{}
+	out := make([]v1alpha1.ObjectReference, 0, len(a)+len(b))
+	for _, r := range append(append([]v1alpha1.ObjectReference{}, a...), b...) {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		out = append(out, r)
+	}
+	return out
+}
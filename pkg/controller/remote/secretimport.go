@@ -0,0 +1,284 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+// We can't watch Secrets within remote clusters, so we poll them more often
+// than other managed resources in order to propagate changes promptly.
+const secretImportPollInterval = 30 * time.Second
+
+// Error strings.
+const (
+	errNotSecretImport    = "managed resource is not a RemoteSecretImport"
+	errNoConnectionSecret = "RemoteSecretImport must specify writeConnectionSecretToRef"
+	errGetImportSecret    = "cannot get imported Secret"
+	errNoImportSecret     = "imported Secret does not exist"
+	errGetMirror          = "cannot get connection secret"
+	errMirrorSecret       = "cannot write connection secret"
+	errDeleteMirror       = "cannot delete connection secret"
+	errMirrorConflict     = "connection secret exists and is not controlled by this RemoteSecretImport"
+)
+
+// SetupRemoteSecretImport adds a controller that reconciles RemoteSecretImport
+// managed resources.
+func SetupRemoteSecretImport(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteSecretImportGroupKind)
+
+	// We write the connection secret ourselves when the imported Secret
+	// changes, because the default publisher neither removes keys nor deletes
+	// the secret.
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteSecretImport{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteSecretImportGroupVersionKind),
+			managed.WithExternalConnecter(&secretImportConnector{kube: mgr.GetClient()}),
+			managed.WithConnectionPublishers(),
+			managed.WithLongWait(secretImportPollInterval),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type secretImportConnector struct {
+	kube client.Client
+}
+
+func (c *secretImportConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteSecretImport)
+	if !ok {
+		return nil, errors.New(errNotSecretImport)
+	}
+
+	remote, err := connect(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	return &secretImportExternal{kube: c.kube, remote: remote}, nil
+}
+
+// A secretImportExternal only ever reads from the remote cluster. It writes
+// only the connection secret, and only in Update.
+type secretImportExternal struct {
+	kube   client.Client
+	remote client.Reader
+}
+
+func (e *secretImportExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteSecretImport)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecretImport)
+	}
+
+	// There's nothing to delete in the remote cluster. Our connection secret
+	// is garbage collected along with us.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if cr.GetWriteConnectionSecretToReference() == nil {
+		return managed.ExternalObservation{}, errors.New(errNoConnectionSecret)
+	}
+
+	mirror, err := e.getMirror(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	s, err := e.getImported(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if s == nil {
+		cr.SetConditions(runtimev1alpha1.Unavailable().WithMessage(errNoImportSecret))
+		stale := cr.Spec.ForProvider.DeleteOnRemoval && mirror != nil
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: !stale}, nil
+	}
+
+	data := importedData(s, cr.Spec.ForProvider.Keys)
+	if mirror == nil || !dataEqual(mirror.Data, data) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
+
+	imported(cr, s, data)
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (e *secretImportExternal) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+// Update writes the imported keys to the connection secret, or deletes the
+// connection secret if the imported Secret was removed and the
+// RemoteSecretImport asks us to.
+func (e *secretImportExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteSecretImport)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSecretImport)
+	}
+
+	s, err := e.getImported(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if s == nil {
+		if !cr.Spec.ForProvider.DeleteOnRemoval {
+			return managed.ExternalUpdate{}, nil
+		}
+		cr.Status.AtProvider = v1alpha1.RemoteSecretImportObservation{}
+		return managed.ExternalUpdate{}, e.deleteMirror(ctx, cr)
+	}
+
+	data := importedData(s, cr.Spec.ForProvider.Keys)
+	if err := e.mirror(ctx, cr, data); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	imported(cr, s, data)
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing; deleting a RemoteSecretImport never deletes the Secret
+// it imports.
+func (e *secretImportExternal) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
+}
+
+// getImported returns the Secret imported by the supplied RemoteSecretImport,
+// or nil if it does not exist.
+func (e *secretImportExternal) getImported(ctx context.Context, cr *v1alpha1.RemoteSecretImport) (*corev1.Secret, error) {
+	ref := cr.Spec.ForProvider.SecretReference
+	s := &corev1.Secret{}
+	err := e.remote.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return s, errors.Wrap(err, errGetImportSecret)
+}
+
+// getMirror returns the connection secret of the supplied RemoteSecretImport,
+// or nil if it does not exist. It returns an error if the connection secret
+// exists but is not controlled by the RemoteSecretImport.
+func (e *secretImportExternal) getMirror(ctx context.Context, cr *v1alpha1.RemoteSecretImport) (*corev1.Secret, error) {
+	ref := cr.GetWriteConnectionSecretToReference()
+	s := &corev1.Secret{}
+	err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetMirror)
+	}
+	if c := metav1.GetControllerOf(s); c == nil || c.UID != cr.GetUID() {
+		return nil, errors.New(errMirrorConflict)
+	}
+	return s, nil
+}
+
+// mirror writes exactly the supplied data to the connection secret of the
+// supplied RemoteSecretImport, removing any keys that are no longer imported.
+func (e *secretImportExternal) mirror(ctx context.Context, cr *v1alpha1.RemoteSecretImport, data map[string][]byte) error {
+	s := resource.ConnectionSecretFor(cr, v1alpha1.RemoteSecretImportGroupVersionKind)
+	_, err := controllerutil.CreateOrUpdate(ctx, e.kube, s, func() error {
+		if c := metav1.GetControllerOf(s); c == nil || c.UID != cr.GetUID() {
+			return errors.New(errMirrorConflict)
+		}
+		s.Data = data
+		return nil
+	})
+	return errors.Wrap(err, errMirrorSecret)
+}
+
+// deleteMirror deletes the connection secret of the supplied
+// RemoteSecretImport, if it controls it.
+func (e *secretImportExternal) deleteMirror(ctx context.Context, cr *v1alpha1.RemoteSecretImport) error {
+	ref := cr.GetWriteConnectionSecretToReference()
+	s := &corev1.Secret{}
+	if err := e.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return errors.Wrap(resource.IgnoreNotFound(err), errDeleteMirror)
+	}
+	if c := metav1.GetControllerOf(s); c == nil || c.UID != cr.GetUID() {
+		return errors.New(errMirrorConflict)
+	}
+	return errors.Wrap(resource.IgnoreNotFound(e.kube.Delete(ctx, s)), errDeleteMirror)
+}
+
+// importedData returns the supplied keys of the supplied Secret, mapped to the
+// keys to which they are imported. All keys are returned if none are supplied.
+// Keys the Secret does not have are omitted.
+func importedData(s *corev1.Secret, keys []v1alpha1.ImportedKey) map[string][]byte {
+	if len(keys) == 0 {
+		return s.Data
+	}
+	data := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		v, ok := s.Data[k.Key]
+		if !ok {
+			continue
+		}
+		to := k.ToKey
+		if to == "" {
+			to = k.Key
+		}
+		data[to] = v
+	}
+	return data
+}
+
+// imported records that the supplied data was imported from the supplied
+// Secret.
+func imported(cr *v1alpha1.RemoteSecretImport, s *corev1.Secret, data map[string][]byte) {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	cr.Status.AtProvider.ResourceVersion = s.GetResourceVersion()
+	cr.Status.AtProvider.ImportedKeys = keys
+	cr.SetConditions(runtimev1alpha1.Available())
+	resource.SetBindable(cr)
+}
+
+// dataEqual returns true if the supplied Secret data are equal. Nil and empty
+// data are considered equal.
+func dataEqual(a, b map[string][]byte) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
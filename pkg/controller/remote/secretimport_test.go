@@ -0,0 +1,173 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+func TestImportedData(t *testing.T) {
+	s := &corev1.Secret{Data: map[string][]byte{
+		"username": []byte("admin"),
+		"password": []byte("secret"),
+	}}
+
+	cases := map[string]struct {
+		reason string
+		keys   []v1alpha1.ImportedKey
+		want   map[string][]byte
+	}{
+		"AllKeys": {
+			reason: "All keys should be imported if none are specified.",
+			want:   s.Data,
+		},
+		"SomeKeys": {
+			reason: "Only the specified keys should be imported.",
+			keys:   []v1alpha1.ImportedKey{{Key: "username"}},
+			want:   map[string][]byte{"username": []byte("admin")},
+		},
+		"RenamedKey": {
+			reason: "Keys should be imported to their toKey, if any.",
+			keys:   []v1alpha1.ImportedKey{{Key: "password", ToKey: "token"}},
+			want:   map[string][]byte{"token": []byte("secret")},
+		},
+		"MissingKey": {
+			reason: "Keys the Secret does not have should be omitted.",
+			keys:   []v1alpha1.ImportedKey{{Key: "username"}, {Key: "endpoint"}},
+			want:   map[string][]byte{"username": []byte("admin")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := importedData(s, tc.keys)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nimportedData(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestSecretImportObserve(t *testing.T) {
+	uid := types.UID("cool-uid")
+	data := map[string][]byte{"username": []byte("admin")}
+	notFound := kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "cool")
+
+	// withSecret returns a MockGetFn that returns a Secret with the supplied
+	// data, controlled by the supplied UID.
+	withSecret := func(controller types.UID, d map[string][]byte) test.MockGetFn {
+		return test.NewMockGetFn(nil, func(o runtime.Object) error {
+			s := o.(*corev1.Secret)
+			s.Data = d
+			s.SetOwnerReferences([]metav1.OwnerReference{{UID: controller, Controller: &[]bool{true}[0]}})
+			return nil
+		})
+	}
+
+	newImport := func(deleteOnRemoval bool) *v1alpha1.RemoteSecretImport {
+		cr := &v1alpha1.RemoteSecretImport{ObjectMeta: metav1.ObjectMeta{UID: uid}}
+		cr.SetWriteConnectionSecretToReference(&runtimev1alpha1.SecretReference{Namespace: "default", Name: "cool"})
+		cr.Spec.ForProvider.SecretReference = runtimev1alpha1.SecretReference{Namespace: "default", Name: "cool"}
+		cr.Spec.ForProvider.DeleteOnRemoval = deleteOnRemoval
+		return cr
+	}
+
+	// The mock clients have no write functions; Observe must not write.
+	cases := map[string]struct {
+		reason  string
+		kube    test.MockGetFn
+		remote  test.MockGetFn
+		cr      *v1alpha1.RemoteSecretImport
+		want    managed.ExternalObservation
+		wantErr error
+	}{
+		"UpToDate": {
+			reason: "The connection secret should be up to date if it contains the imported keys.",
+			kube:   withSecret(uid, data),
+			remote: withSecret("", data),
+			cr:     newImport(false),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"NoConnectionSecret": {
+			reason: "A missing connection secret should need an update.",
+			kube:   test.NewMockGetFn(notFound),
+			remote: withSecret("", data),
+			cr:     newImport(false),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"ChangedSecret": {
+			reason: "A connection secret whose keys differ from the imported Secret should need an update.",
+			kube:   withSecret(uid, map[string][]byte{"username": []byte("old")}),
+			remote: withSecret("", data),
+			cr:     newImport(false),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"RemovedSecretRetained": {
+			reason: "The connection secret should be retained when the imported Secret is removed, unless deleteOnRemoval is set.",
+			kube:   withSecret(uid, data),
+			remote: test.NewMockGetFn(notFound),
+			cr:     newImport(false),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+		},
+		"RemovedSecretDeleted": {
+			reason: "The connection secret should need an update when the imported Secret is removed and deleteOnRemoval is set.",
+			kube:   withSecret(uid, data),
+			remote: test.NewMockGetFn(notFound),
+			cr:     newImport(true),
+			want:   managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+		},
+		"Conflict": {
+			reason:  "A connection secret controlled by something else should not be touched.",
+			kube:    withSecret("other-uid", data),
+			remote:  withSecret("", data),
+			cr:      newImport(false),
+			wantErr: errors.New(errMirrorConflict),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &secretImportExternal{
+				kube:   &test.MockClient{MockGet: tc.kube},
+				remote: &test.MockClient{MockGet: tc.remote},
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
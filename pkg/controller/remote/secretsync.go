@@ -0,0 +1,471 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	containerv1beta1 "github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+)
+
+const (
+	// SecretSyncFinalizer is added to a RemoteSecretSync so that its copies
+	// may be deleted before it is.
+	SecretSyncFinalizer = "finalizer.remotesecretsync.remote.dev.crossplane.io"
+
+	syncTimeout = 2 * time.Minute
+	syncRetry   = 30 * time.Second
+
+	// We sync each cluster periodically, whether or not the source changed,
+	// in order to restore copies that were changed or deleted.
+	resyncPeriod = 10 * time.Minute
+)
+
+// Error strings.
+const (
+	errGetSecretSync     = "cannot get RemoteSecretSync"
+	errGetSyncSource     = "cannot get source of RemoteSecretSync"
+	errUnknownSourceKind = "unknown source kind"
+	errSyncSelector      = "cannot parse RemoteSecretSync cluster selector"
+	errListSyncClusters  = "cannot list ExistingClusters"
+	errGetCopy           = "cannot get copy of source"
+	errCreateCopy        = "cannot create copy of source"
+	errUpdateCopy        = "cannot update copy of source"
+	errDeleteCopy        = "cannot delete copy of source"
+	errNotOurCopy        = "an object with the name of the copy exists, and was not created by this RemoteSecretSync"
+	errSyncClusters      = "cannot sync source to some clusters"
+	errUnsyncClusters    = "cannot delete copies of source from some clusters"
+	errUpdateSecretSync  = "cannot update RemoteSecretSync"
+	errUpdateSyncStatus  = "cannot update RemoteSecretSync status"
+)
+
+// Event reasons.
+const (
+	reasonSynced        event.Reason = "SyncedSource"
+	reasonCannotSync    event.Reason = "CannotSyncSource"
+	reasonMissingSource event.Reason = "MissingSource"
+)
+
+// SetupRemoteSecretSync adds a controller that copies the source of each
+// RemoteSecretSync to the clusters it targets.
+func SetupRemoteSecretSync(mgr ctrl.Manager, l logging.Logger) error {
+	name := "remote/" + strings.ToLower(v1alpha1.RemoteSecretSyncKind)
+
+	r := &SecretSyncReconciler{
+		client: mgr.GetClient(),
+		log:    l.WithValues("controller", name),
+		record: event.NewAPIRecorder(mgr.GetEventRecorderFor(name)),
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteSecretSync{}).
+		Watches(&source.Kind{Type: &corev1.Secret{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: &SyncsOf{client: mgr.GetClient(), kind: v1alpha1.SyncSourceSecret}}).
+		Watches(&source.Kind{Type: &corev1.ConfigMap{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: &SyncsOf{client: mgr.GetClient(), kind: v1alpha1.SyncSourceConfigMap}}).
+		Watches(&source.Kind{Type: &containerv1beta1.ExistingCluster{}}, &handler.EnqueueRequestsFromMapFunc{ToRequests: &SyncsTargeting{client: mgr.GetClient()}}).
+		Complete(r)
+}
+
+// SyncsOf maps a Secret or ConfigMap to requests for the RemoteSecretSyncs of
+// which it is the source.
+type SyncsOf struct {
+	client client.Reader
+	kind   v1alpha1.SyncSourceKind
+}
+
+// Map a Secret or ConfigMap to requests for the RemoteSecretSyncs of which it
+// is the source.
+func (m *SyncsOf) Map(o handler.MapObject) []reconcile.Request {
+	l := &v1alpha1.RemoteSecretSyncList{}
+	if err := m.client.List(context.TODO(), l); err != nil {
+		return nil
+	}
+
+	rs := []reconcile.Request{}
+	for _, s := range l.Items {
+		src := s.Spec.Source
+		if src.Kind != m.kind || src.Namespace != o.Meta.GetNamespace() || src.Name != o.Meta.GetName() {
+			continue
+		}
+		rs = append(rs, reconcile.Request{NamespacedName: types.NamespacedName{Name: s.GetName()}})
+	}
+	return rs
+}
+
+// SyncsTargeting maps an ExistingCluster to requests for the RemoteSecretSyncs
+// that target it.
+type SyncsTargeting struct {
+	client client.Reader
+}
+
+// Map an ExistingCluster to requests for the RemoteSecretSyncs that target it.
+func (m *SyncsTargeting) Map(o handler.MapObject) []reconcile.Request {
+	l := &v1alpha1.RemoteSecretSyncList{}
+	if err := m.client.List(context.TODO(), l); err != nil {
+		return nil
+	}
+
+	rs := []reconcile.Request{}
+	for i := range l.Items {
+		if s := &l.Items[i]; targets(s, o.Meta) {
+			rs = append(rs, reconcile.Request{NamespacedName: types.NamespacedName{Name: s.GetName()}})
+		}
+	}
+	return rs
+}
+
+// targets returns true if the supplied RemoteSecretSync targets the supplied
+// ExistingCluster.
+func targets(s *v1alpha1.RemoteSecretSync, ec metav1.Object) bool {
+	for _, ref := range s.Spec.ClusterReferences {
+		if ref.Name == ec.GetName() {
+			return true
+		}
+	}
+	if s.Spec.ClusterSelector == nil {
+		return false
+	}
+	sel, err := metav1.LabelSelectorAsSelector(s.Spec.ClusterSelector)
+	return err == nil && sel.Matches(labels.Set(ec.GetLabels()))
+}
+
+// A SecretSyncReconciler copies the source of a RemoteSecretSync to each of the
+// clusters it targets. The source is copied to a cluster when the
+// RemoteSecretSync or its source change, and periodically.
+type SecretSyncReconciler struct {
+	client client.Client
+	log    logging.Logger
+	record event.Recorder
+}
+
+// Reconcile a RemoteSecretSync by copying its source to the clusters it
+// targets.
+func (r *SecretSyncReconciler) Reconcile(req reconcile.Request) (reconcile.Result, error) {
+	log := r.log.WithValues("request", req)
+	log.Debug("Reconciling")
+
+	ctx, cancel := context.WithTimeout(context.Background(), syncTimeout)
+	defer cancel()
+
+	s := &v1alpha1.RemoteSecretSync{}
+	if err := r.client.Get(ctx, req.NamespacedName, s); err != nil {
+		// There's no need to requeue if we no longer exist. Otherwise we'll be
+		// requeued implicitly because we return an error.
+		log.Debug("Cannot get RemoteSecretSync", "error", err)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(err), errGetSecretSync)
+	}
+
+	if meta.WasDeleted(s) {
+		// Clusters whose copies cannot be deleted remain in our status, so
+		// that we may retry.
+		if remaining := r.unsync(ctx, s, s.Status.Clusters); len(remaining) > 0 {
+			err := errors.Errorf("%s: %d of %d failed", errUnsyncClusters, len(remaining), len(s.Status.Clusters))
+			s.Status.Clusters = remaining
+			s.Status.SetConditions(runtimev1alpha1.Deleting(), runtimev1alpha1.ReconcileError(err))
+			return reconcile.Result{RequeueAfter: syncRetry}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+		}
+		meta.RemoveFinalizer(s, SecretSyncFinalizer)
+		return reconcile.Result{}, errors.Wrap(resource.IgnoreNotFound(r.client.Update(ctx, s)), errUpdateSecretSync)
+	}
+
+	if !meta.FinalizerExists(s, SecretSyncFinalizer) {
+		meta.AddFinalizer(s, SecretSyncFinalizer)
+		if err := r.client.Update(ctx, s); err != nil {
+			log.Debug("Cannot add RemoteSecretSync finalizer", "error", err)
+			return reconcile.Result{}, errors.Wrap(err, errUpdateSecretSync)
+		}
+	}
+
+	src, err := r.source(ctx, s)
+	if err != nil {
+		// We'll be requeued when the source is created.
+		log.Debug("Cannot get source", "error", err)
+		r.record.Event(s, event.Warning(reasonMissingSource, err))
+		s.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+	}
+	rv := src.(metav1.Object).GetResourceVersion()
+
+	names, err := r.targets(ctx, s)
+	if err != nil {
+		s.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: syncRetry}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+	}
+
+	previous := map[string]v1alpha1.ClusterSync{}
+	for _, cs := range s.Status.Clusters {
+		previous[cs.ClusterName] = cs
+	}
+
+	// Copies are deleted from clusters that we no longer target.
+	targeted := map[string]bool{}
+	for _, name := range names {
+		targeted[name] = true
+	}
+	dropped := []v1alpha1.ClusterSync{}
+	for _, cs := range s.Status.Clusters {
+		if !targeted[cs.ClusterName] {
+			dropped = append(dropped, cs)
+		}
+	}
+	remaining := r.unsync(ctx, s, dropped)
+
+	now := metav1.Now()
+	failed := 0
+	clusters := make([]v1alpha1.ClusterSync, 0, len(names))
+	cp := copyOf(s, src)
+	ref := copyReference(s, cp)
+	for _, name := range names {
+		cs, ok := previous[name]
+		if ok && cs.Error == "" && cs.ResourceVersion == rv && cs.ObservedGeneration == s.GetGeneration() &&
+			cs.Copy != nil && *cs.Copy == ref && cs.Time != nil && now.Sub(cs.Time.Time) < resyncPeriod {
+			clusters = append(clusters, cs)
+			continue
+		}
+
+		// The copy must be moved if our spec changed where it should be.
+		var stale *v1alpha1.ObjectReference
+		if cs.Copy != nil && *cs.Copy != ref {
+			stale = cs.Copy
+		}
+
+		cs.ClusterName = name
+		if err := r.sync(ctx, s, name, cp.DeepCopyObject(), stale); err != nil {
+			log.Debug("Cannot sync source to cluster", "cluster", name, "error", err)
+			r.record.Event(s, event.Warning(reasonCannotSync, errors.Wrap(err, name)))
+			cs.Error = err.Error()
+			failed++
+			clusters = append(clusters, cs)
+			continue
+		}
+		if cs.ResourceVersion != rv {
+			r.record.Event(s, event.Normal(reasonSynced, "Synced resource version "+rv+" of source to ExistingCluster "+name))
+		}
+		cs.ResourceVersion = rv
+		cs.ObservedGeneration = s.GetGeneration()
+		cs.Copy = &ref
+		cs.Time = &now
+		cs.Error = ""
+		clusters = append(clusters, cs)
+	}
+
+	s.Status.ResourceVersion = rv
+	s.Status.Clusters = append(clusters, remaining...)
+	if len(remaining) > 0 {
+		err := errors.Errorf("%s: %d of %d failed", errUnsyncClusters, len(remaining), len(dropped))
+		s.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: syncRetry}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+	}
+	if failed > 0 {
+		err := errors.Errorf("%s: %d of %d failed", errSyncClusters, failed, len(names))
+		s.Status.SetConditions(runtimev1alpha1.Unavailable(), runtimev1alpha1.ReconcileError(err))
+		return reconcile.Result{RequeueAfter: syncRetry}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+	}
+
+	s.Status.SetConditions(runtimev1alpha1.Available(), runtimev1alpha1.ReconcileSuccess())
+	return reconcile.Result{RequeueAfter: resyncPeriod}, errors.Wrap(r.client.Status().Update(ctx, s), errUpdateSyncStatus)
+}
+
+// source returns the source of the supplied RemoteSecretSync.
+func (r *SecretSyncReconciler) source(ctx context.Context, s *v1alpha1.RemoteSecretSync) (runtime.Object, error) {
+	var src runtime.Object
+	switch s.Spec.Source.Kind {
+	case v1alpha1.SyncSourceSecret:
+		src = &corev1.Secret{}
+	case v1alpha1.SyncSourceConfigMap:
+		src = &corev1.ConfigMap{}
+	default:
+		return nil, errors.Errorf("%s: %s", errUnknownSourceKind, s.Spec.Source.Kind)
+	}
+	nn := types.NamespacedName{Namespace: s.Spec.Source.Namespace, Name: s.Spec.Source.Name}
+	return src, errors.Wrap(r.client.Get(ctx, nn, src), errGetSyncSource)
+}
+
+// targets returns the names of the ExistingClusters targeted by the supplied
+// RemoteSecretSync, sorted by name.
+func (r *SecretSyncReconciler) targets(ctx context.Context, s *v1alpha1.RemoteSecretSync) ([]string, error) {
+	set := map[string]bool{}
+	for _, ref := range s.Spec.ClusterReferences {
+		set[ref.Name] = true
+	}
+
+	if s.Spec.ClusterSelector != nil {
+		sel, err := metav1.LabelSelectorAsSelector(s.Spec.ClusterSelector)
+		if err != nil {
+			return nil, errors.Wrap(err, errSyncSelector)
+		}
+		l := &containerv1beta1.ExistingClusterList{}
+		if err := r.client.List(ctx, l, client.MatchingLabelsSelector{Selector: sel}); err != nil {
+			return nil, errors.Wrap(err, errListSyncClusters)
+		}
+		for _, ec := range l.Items {
+			set[ec.GetName()] = true
+		}
+	}
+
+	names := make([]string, 0, len(set))
+	for n := range set {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// sync the supplied copy of the source of the supplied RemoteSecretSync to the
+// cluster represented by the named ExistingCluster, then delete the supplied
+// stale copy, if any. A copy is only updated or deleted if it was created by
+// the RemoteSecretSync.
+func (r *SecretSyncReconciler) sync(ctx context.Context, s *v1alpha1.RemoteSecretSync, name string, desired runtime.Object, stale *v1alpha1.ObjectReference) error {
+	remote, err := r.connect(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	if err := putCopy(ctx, remote, s, desired); err != nil {
+		return err
+	}
+	if stale == nil {
+		return nil
+	}
+	return deleteCopy(ctx, remote, s, *stale)
+}
+
+// unsync deletes the copies of the source of the supplied RemoteSecretSync
+// from the supplied clusters. It returns the clusters from which copies could
+// not be deleted, recording why. Clusters whose ExistingCluster no longer
+// exists cannot be reached, and are forgotten.
+func (r *SecretSyncReconciler) unsync(ctx context.Context, s *v1alpha1.RemoteSecretSync, clusters []v1alpha1.ClusterSync) []v1alpha1.ClusterSync {
+	remaining := []v1alpha1.ClusterSync{}
+	for _, cs := range clusters {
+		if cs.Copy == nil {
+			continue
+		}
+		remote, err := r.connect(ctx, cs.ClusterName)
+		if kerrors.IsNotFound(errors.Cause(err)) {
+			continue
+		}
+		if err == nil {
+			err = deleteCopy(ctx, remote, s, *cs.Copy)
+		}
+		if err != nil {
+			r.record.Event(s, event.Warning(reasonCannotSync, errors.Wrap(err, cs.ClusterName)))
+			cs.Error = err.Error()
+			remaining = append(remaining, cs)
+		}
+	}
+	return remaining
+}
+
+// connect returns a client for the cluster represented by the named
+// ExistingCluster.
+func (r *SecretSyncReconciler) connect(ctx context.Context, name string) (client.Client, error) {
+	p, err := providerOf(ctx, r.client, &corev1.ObjectReference{Name: name})
+	if err != nil {
+		return nil, err
+	}
+	remote, err := cluster.ClientFor(ctx, r.client, p)
+	return remote, errors.Wrap(err, errConnectToRemote)
+}
+
+// putCopy creates or updates the supplied copy of the source of the supplied
+// RemoteSecretSync.
+func putCopy(ctx context.Context, remote client.Client, s *v1alpha1.RemoteSecretSync, desired runtime.Object) error {
+	d := desired.(metav1.Object)
+	current := desired.DeepCopyObject()
+	err := remote.Get(ctx, types.NamespacedName{Namespace: d.GetNamespace(), Name: d.GetName()}, current)
+	if kerrors.IsNotFound(err) {
+		return errors.Wrap(remote.Create(ctx, desired), errCreateCopy)
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetCopy)
+	}
+
+	c := current.(metav1.Object)
+	if !ownedBy(c, s) {
+		return errors.New(errNotOurCopy)
+	}
+	d.SetResourceVersion(c.GetResourceVersion())
+	return errors.Wrap(remote.Update(ctx, desired), errUpdateCopy)
+}
+
+// deleteCopy deletes the referenced copy of the source of the supplied
+// RemoteSecretSync. Objects that do not exist or were not created by the
+// RemoteSecretSync are ignored.
+func deleteCopy(ctx context.Context, remote client.Client, s *v1alpha1.RemoteSecretSync, ref v1alpha1.ObjectReference) error {
+	return errors.Wrap(deleteOwned(ctx, remote, objectFor(ref), s), errDeleteCopy)
+}
+
+// copyReference returns a reference to the supplied copy of the source of the
+// supplied RemoteSecretSync.
+func copyReference(s *v1alpha1.RemoteSecretSync, cp runtime.Object) v1alpha1.ObjectReference {
+	o := cp.(metav1.Object)
+	return v1alpha1.ObjectReference{
+		APIVersion: corev1.SchemeGroupVersion.String(),
+		Kind:       string(s.Spec.Source.Kind),
+		Namespace:  o.GetNamespace(),
+		Name:       o.GetName(),
+	}
+}
+
+// copyOf returns a copy of the supplied source, as it should exist in each
+// cluster targeted by the supplied RemoteSecretSync.
+func copyOf(s *v1alpha1.RemoteSecretSync, src runtime.Object) runtime.Object {
+	om := func(o metav1.Object) metav1.ObjectMeta {
+		name := s.Spec.Name
+		if name == "" {
+			name = o.GetName()
+		}
+		return metav1.ObjectMeta{
+			Namespace:   s.Spec.Namespace,
+			Name:        name,
+			Labels:      managedLabels(nil),
+			Annotations: ownerAnnotations(nil, s),
+		}
+	}
+
+	switch src := src.(type) {
+	case *corev1.Secret:
+		return &corev1.Secret{ObjectMeta: om(src), Type: src.Type, Data: src.Data}
+	case *corev1.ConfigMap:
+		return &corev1.ConfigMap{ObjectMeta: om(src), Data: src.Data, BinaryData: src.BinaryData}
+	}
+	return nil
+}
@@ -0,0 +1,367 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	runtimev1alpha1 "github.com/crossplaneio/crossplane-runtime/apis/core/v1alpha1"
+	"github.com/crossplaneio/crossplane-runtime/pkg/event"
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplaneio/crossplane-runtime/pkg/resource"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+	"github.com/turkenh/provider-existing-cluster/pkg/clients/cluster"
+)
+
+// Error strings.
+const (
+	errNotTenant        = "managed resource is not a RemoteTenant"
+	errGetQuota         = "cannot get tenant ResourceQuota"
+	errGetAdminToken    = "cannot get tenant admin token Secret"
+	errTenantKubeconfig = "cannot create tenant kubeconfig"
+	errReservedBinding  = "role binding name " + tenantAdminName + " is reserved for the tenant admin"
+)
+
+// Names of the objects that make up a tenant, within the tenant's namespace.
+const (
+	tenantQuotaName      = "tenant"
+	tenantLimitsName     = "tenant"
+	tenantIsolationName  = "tenant-isolation"
+	tenantAdminName      = "tenant-admin"
+	tenantAdminTokenName = "tenant-admin-token"
+)
+
+// tenantAdminRole is the ClusterRole granted within the tenant's namespace to
+// the ServiceAccount whose kubeconfig is published.
+const tenantAdminRole = "admin"
+
+// SetupRemoteTenant adds a controller that reconciles RemoteTenant managed
+// resources.
+func SetupRemoteTenant(mgr ctrl.Manager, l logging.Logger) error {
+	name := managed.ControllerName(v1alpha1.RemoteTenantGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.RemoteTenant{}).
+		Complete(managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.RemoteTenantGroupVersionKind),
+			managed.WithExternalConnecter(&tenantConnector{kube: mgr.GetClient()}),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+}
+
+type tenantConnector struct {
+	kube client.Client
+}
+
+func (c *tenantConnector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.RemoteTenant)
+	if !ok {
+		return nil, errors.New(errNotTenant)
+	}
+
+	p, err := providerFor(ctx, c.kube, cr)
+	if err != nil {
+		return orphan(cr, err)
+	}
+	kc, err := cluster.GetKubeconfig(ctx, c.kube, p)
+	if err != nil {
+		return orphan(cr, errors.Wrap(err, errGetKubeconfig))
+	}
+	remote, err := cluster.ClientForKubeconfig(kc)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectToRemote)
+	}
+	return &tenantExternal{remote: remote, kubeconfig: kc}, nil
+}
+
+type tenantExternal struct {
+	remote client.Client
+
+	// kubeconfig used to connect to the cluster. The tenant's kubeconfig
+	// connects to the same server.
+	kubeconfig []byte
+}
+
+func (e *tenantExternal) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteTenant)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotTenant)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := e.remote.Get(ctx, types.NamespacedName{Name: meta.GetExternalName(cr)}, ns); err != nil {
+		if kerrors.IsNotFound(err) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetNamespace)
+	}
+
+	// A namespace we did not create, for example another team's, is never
+	// adopted.
+	if !ownedBy(ns, cr) {
+		if meta.WasDeleted(cr) {
+			return managed.ExternalObservation{ResourceExists: false}, nil
+		}
+		return managed.ExternalObservation{}, errors.New(errNotOurNamespace)
+	}
+	cr.Status.AtProvider.Phase = string(ns.Status.Phase)
+
+	// Every other object of the tenant is deleted along with its namespace.
+	if meta.WasDeleted(cr) {
+		cr.SetConditions(runtimev1alpha1.Deleting())
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+
+	desired, err := tenantObjects(cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	upToDate := inventoryEqual(cr.Status.AtProvider.Inventory, desired)
+	for _, o := range desired {
+		observed := &unstructured.Unstructured{}
+		observed.SetGroupVersionKind(o.GroupVersionKind())
+		err := e.remote.Get(ctx, types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()}, observed)
+		if kerrors.IsNotFound(err) {
+			upToDate = false
+			continue
+		}
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetObject)
+		}
+		if !isSubset(o.Object, observed.Object) {
+			upToDate = false
+		}
+	}
+
+	if err := e.observeQuota(ctx, cr); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	kc, err := e.tenantKubeconfig(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	var cd managed.ConnectionDetails
+	if kc != nil {
+		cd = managed.ConnectionDetails{runtimev1alpha1.ResourceCredentialsSecretKubeconfigKey: kc}
+	}
+
+	if ns.Status.Phase == corev1.NamespaceActive && kc != nil {
+		cr.SetConditions(runtimev1alpha1.Available())
+		resource.SetBindable(cr)
+	} else {
+		cr.SetConditions(runtimev1alpha1.Unavailable())
+	}
+
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: upToDate, ConnectionDetails: cd}, nil
+}
+
+func (e *tenantExternal) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.RemoteTenant)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotTenant)
+	}
+	cr.SetConditions(runtimev1alpha1.Creating())
+
+	return managed.ExternalCreation{}, e.sync(ctx, cr)
+}
+
+func (e *tenantExternal) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.RemoteTenant)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotTenant)
+	}
+
+	return managed.ExternalUpdate{}, e.sync(ctx, cr)
+}
+
+func (e *tenantExternal) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.RemoteTenant)
+	if !ok {
+		return errors.New(errNotTenant)
+	}
+	cr.SetConditions(runtimev1alpha1.Deleting())
+
+	return deleteNamespace(ctx, e.remote, meta.GetExternalName(cr), cr)
+}
+
+// sync applies the objects that make up the supplied RemoteTenant, then prunes
+// any object of its inventory that is no longer part of the tenant. The
+// inventory is updated to record the objects that were applied.
+func (e *tenantExternal) sync(ctx context.Context, cr *v1alpha1.RemoteTenant) error {
+	desired, err := tenantObjects(cr)
+	if err != nil {
+		return err
+	}
+
+	previous := cr.Status.AtProvider.Inventory
+	applied := make([]v1alpha1.ObjectReference, 0, len(desired))
+	for _, o := range desired {
+		if err := apply(ctx, e.remote, o); err != nil {
+			cr.Status.AtProvider.Inventory = union(previous, applied)
+			return err
+		}
+		applied = append(applied, referenceTo(o))
+	}
+
+	if err := deleteAll(ctx, e.remote, previous, applied); err != nil {
+		cr.Status.AtProvider.Inventory = union(previous, applied)
+		return errors.Wrap(err, errPruneObject)
+	}
+
+	cr.Status.AtProvider.Inventory = applied
+	return nil
+}
+
+// observeQuota records the quota of the supplied RemoteTenant's namespace, and
+// its usage.
+func (e *tenantExternal) observeQuota(ctx context.Context, cr *v1alpha1.RemoteTenant) error {
+	cr.Status.AtProvider.Quota = nil
+	if len(cr.Spec.ForProvider.Quota) == 0 {
+		return nil
+	}
+
+	rq := &corev1.ResourceQuota{}
+	err := e.remote.Get(ctx, types.NamespacedName{Namespace: meta.GetExternalName(cr), Name: tenantQuotaName}, rq)
+	if kerrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, errGetQuota)
+	}
+	cr.Status.AtProvider.Quota = &rq.Status
+	return nil
+}
+
+// tenantKubeconfig returns a kubeconfig that administers the supplied
+// RemoteTenant's namespace, or nil if the token of the tenant's admin
+// ServiceAccount has yet to be issued.
+func (e *tenantExternal) tenantKubeconfig(ctx context.Context, cr *v1alpha1.RemoteTenant) ([]byte, error) {
+	name := meta.GetExternalName(cr)
+	s := &corev1.Secret{}
+	err := e.remote.Get(ctx, types.NamespacedName{Namespace: name, Name: tenantAdminTokenName}, s)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAdminToken)
+	}
+
+	token := s.Data[corev1.ServiceAccountTokenKey]
+	if len(token) == 0 {
+		return nil, nil
+	}
+	kc, err := cluster.TokenKubeconfig(e.kubeconfig, name, name, string(token))
+	return kc, errors.Wrap(err, errTenantKubeconfig)
+}
+
+// tenantObjects returns the objects that make up the supplied RemoteTenant, in
+// the order in which they must be applied.
+func tenantObjects(cr *v1alpha1.RemoteTenant) ([]*unstructured.Unstructured, error) {
+	name := meta.GetExternalName(cr)
+	p := cr.Spec.ForProvider
+	om := func(n string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Namespace:   name,
+			Name:        n,
+			Labels:      managedLabels(nil),
+			Annotations: ownerAnnotations(nil, cr),
+		}
+	}
+
+	objs := []runtime.Object{
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: managedLabels(p.Labels), Annotations: ownerAnnotations(p.Annotations, cr)}},
+	}
+	if len(p.Quota) > 0 {
+		objs = append(objs, &corev1.ResourceQuota{
+			ObjectMeta: om(tenantQuotaName),
+			Spec:       corev1.ResourceQuotaSpec{Hard: p.Quota},
+		})
+	}
+	if len(p.Limits) > 0 {
+		objs = append(objs, &corev1.LimitRange{
+			ObjectMeta: om(tenantLimitsName),
+			Spec:       corev1.LimitRangeSpec{Limits: p.Limits},
+		})
+	}
+	if i := p.Isolation; i != nil {
+		from := []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}}
+		if i.AllowFromNamespaces != nil {
+			from = append(from, networkingv1.NetworkPolicyPeer{NamespaceSelector: i.AllowFromNamespaces})
+		}
+		objs = append(objs, &networkingv1.NetworkPolicy{
+			ObjectMeta: om(tenantIsolationName),
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+				Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: from}},
+			},
+		})
+	}
+
+	// The token controller deletes a token Secret whose ServiceAccount does
+	// not exist, so the ServiceAccount must be applied first.
+	token := om(tenantAdminTokenName)
+	token.Annotations[corev1.ServiceAccountNameKey] = tenantAdminName
+	objs = append(objs,
+		&corev1.ServiceAccount{ObjectMeta: om(tenantAdminName)},
+		&corev1.Secret{ObjectMeta: token, Type: corev1.SecretTypeServiceAccountToken},
+		&rbacv1.RoleBinding{
+			ObjectMeta: om(tenantAdminName),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: tenantAdminRole},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: name, Name: tenantAdminName}},
+		},
+	)
+	for _, rb := range p.RoleBindings {
+		if rb.Name == tenantAdminName {
+			return nil, errors.New(errReservedBinding)
+		}
+		objs = append(objs, &rbacv1.RoleBinding{
+			ObjectMeta: om(rb.Name),
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: rb.ClusterRole},
+			Subjects:   rb.Subjects,
+		})
+	}
+
+	out := make([]*unstructured.Unstructured, 0, len(objs))
+	for _, o := range objs {
+		u, err := cluster.ToUnstructured(o)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
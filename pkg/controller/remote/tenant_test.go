@@ -0,0 +1,121 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/meta"
+	"github.com/crossplaneio/crossplane-runtime/pkg/test"
+
+	"github.com/turkenh/provider-existing-cluster/apis/remote/v1alpha1"
+)
+
+func TestTenantObjects(t *testing.T) {
+	newTenant := func(p v1alpha1.RemoteTenantParameters) *v1alpha1.RemoteTenant {
+		cr := &v1alpha1.RemoteTenant{ObjectMeta: metav1.ObjectMeta{UID: types.UID("cool-uid")}}
+		meta.SetExternalName(cr, "cool")
+		cr.Spec.ForProvider = p
+		return cr
+	}
+
+	// The objects every tenant has.
+	admin := []string{
+		"ServiceAccount/cool/" + tenantAdminName,
+		"Secret/cool/" + tenantAdminTokenName,
+		"RoleBinding/cool/" + tenantAdminName,
+	}
+
+	type want struct {
+		objects []string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason string
+		cr     *v1alpha1.RemoteTenant
+		want   want
+	}{
+		"Minimal": {
+			reason: "A tenant should have a namespace and an admin by default.",
+			cr:     newTenant(v1alpha1.RemoteTenantParameters{}),
+			want:   want{objects: append([]string{"Namespace//cool"}, admin...)},
+		},
+		"Quota": {
+			reason: "A tenant with a quota should have a ResourceQuota.",
+			cr: newTenant(v1alpha1.RemoteTenantParameters{
+				Quota: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}),
+			want: want{objects: append([]string{"Namespace//cool", "ResourceQuota/cool/" + tenantQuotaName}, admin...)},
+		},
+		"Limits": {
+			reason: "A tenant with limits should have a LimitRange.",
+			cr: newTenant(v1alpha1.RemoteTenantParameters{
+				Limits: []corev1.LimitRangeItem{{Type: corev1.LimitTypeContainer}},
+			}),
+			want: want{objects: append([]string{"Namespace//cool", "LimitRange/cool/" + tenantLimitsName}, admin...)},
+		},
+		"Isolation": {
+			reason: "An isolated tenant should have a NetworkPolicy.",
+			cr: newTenant(v1alpha1.RemoteTenantParameters{
+				Isolation: &v1alpha1.TenantIsolation{},
+			}),
+			want: want{objects: append([]string{"Namespace//cool", "NetworkPolicy/cool/" + tenantIsolationName}, admin...)},
+		},
+		"RoleBindings": {
+			reason: "Role bindings should be applied after the admin.",
+			cr: newTenant(v1alpha1.RemoteTenantParameters{
+				RoleBindings: []v1alpha1.TenantRoleBinding{{Name: "viewers", ClusterRole: "view"}},
+			}),
+			want: want{objects: append(append([]string{"Namespace//cool"}, admin...), "RoleBinding/cool/viewers")},
+		},
+		"ReservedRoleBinding": {
+			reason: "A role binding may not replace the tenant admin's.",
+			cr: newTenant(v1alpha1.RemoteTenantParameters{
+				RoleBindings: []v1alpha1.TenantRoleBinding{{Name: tenantAdminName, ClusterRole: "cluster-admin"}},
+			}),
+			want: want{err: errors.New(errReservedBinding)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			objs, err := tenantObjects(tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ntenantObjects(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+
+			var got []string
+			for _, o := range objs {
+				got = append(got, o.GetKind()+"/"+o.GetNamespace()+"/"+o.GetName())
+				if !ownedBy(o, tc.cr) {
+					t.Errorf("\n%s\ntenantObjects(...): %s is not marked as created by the RemoteTenant", tc.reason, o.GetName())
+				}
+			}
+			if diff := cmp.Diff(tc.want.objects, got); diff != "" {
+				t.Errorf("\n%s\ntenantObjects(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package container contains admission webhooks for container resources.
+package container
+
+import (
+	"context"
+	"net/http"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+
+	"github.com/turkenh/provider-existing-cluster/apis/container/v1beta1"
+)
+
+// ExistingClusterValidatorPath is the path at which the ExistingCluster
+// validating webhook is served.
+const ExistingClusterValidatorPath = "/validate-container-dev-crossplane-io-v1beta1-existingcluster"
+
+// Error strings.
+const (
+	errDeletionProtected = "deletion protection is enabled; set spec.forProvider.deletionProtection to false before deleting this ExistingCluster"
+)
+
+// SetupExistingCluster registers a webhook that validates ExistingCluster
+// managed resources.
+func SetupExistingCluster(mgr ctrl.Manager, l logging.Logger) error {
+	mgr.GetWebhookServer().Register(ExistingClusterValidatorPath, &webhook.Admission{Handler: &existingClusterValidator{}})
+	return nil
+}
+
+type existingClusterValidator struct {
+	decoder *admission.Decoder
+}
+
+// InjectDecoder injects the decoder used to decode admission requests.
+func (v *existingClusterValidator) InjectDecoder(d *admission.Decoder) error {
+	v.decoder = d
+	return nil
+}
+
+// Handle denies the deletion of ExistingClusters with deletion protection
+// enabled. All other operations are allowed.
+func (v *existingClusterValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1beta1.Delete {
+		return admission.Allowed("")
+	}
+
+	// The object being deleted is supplied as the old object.
+	cr := &v1beta1.ExistingCluster{}
+	if err := v.decoder.DecodeRaw(req.OldObject, cr); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	if cr.Spec.ForProvider.DeletionProtection {
+		return admission.Denied(errDeletionProtected)
+	}
+	return admission.Allowed("")
+}
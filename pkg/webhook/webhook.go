@@ -0,0 +1,39 @@
+/*
+Copyright 2019 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhook contains admission webhooks for this provider's resources.
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
+
+	"github.com/turkenh/provider-existing-cluster/pkg/webhook/container"
+)
+
+// Setup registers all admission webhooks with the supplied manager's webhook
+// server.
+func Setup(mgr ctrl.Manager, l logging.Logger) error {
+	for _, setup := range []func(ctrl.Manager, logging.Logger) error{
+		container.SetupExistingCluster,
+	} {
+		if err := setup(mgr, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
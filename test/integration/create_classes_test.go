@@ -24,6 +24,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/crossplaneio/crossplane-runtime/pkg/logging"
 	"github.com/crossplaneio/crossplane-runtime/pkg/test/integration"
 	crossplaneapis "github.com/crossplaneio/crossplane/apis"
 	"k8s.io/client-go/tools/clientcmd"
@@ -73,7 +74,7 @@ func TestCreateAllClasses(t *testing.T) {
 	}
 
 	i, err := integration.New(cfg,
-		integration.WithCRDPaths("../../config/crd"),
+		integration.WithCRDPaths("../../package/crds"),
 		integration.WithCleaners(
 			integration.NewCRDCleaner(),
 			integration.NewCRDDirCleaner()),
@@ -91,7 +92,7 @@ func TestCreateAllClasses(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	if err := (&controller.Controllers{}).SetupWithManager(i); err != nil {
+	if err := controller.Setup(i, logging.NewNopLogger()); err != nil {
 		t.Fatal(err)
 	}
 